@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"time"
+	// Встраиваем базу часовых поясов: в образе alpine её нет
+	_ "time/tzdata"
 
 	"github.com/linspacestrom/InterShipAv/internal/config"
 	"github.com/linspacestrom/InterShipAv/internal/db"
@@ -35,13 +38,38 @@ func main() {
 	teamRepo := repositories.NewTeamRepository(pool)
 	userRepo := repositories.NewUserRepository(pool)
 	prRepo := repositories.NewPullRequestRepository(pool)
+	codeOwnerRepo := repositories.NewCodeOwnerRepository(pool)
+	ruleRepo := repositories.NewReviewerRuleRepository(pool)
+	absenceRepo := repositories.NewAbsenceRepository(pool)
+	declineRepo := repositories.NewDeclineRepository(pool)
+	revisionRepo := repositories.NewRevisionRepository(pool)
+	eventRepo := repositories.NewEventRepository(pool)
 	tm := transaction.NewManager(pool)
+	clock := services.NewSystemClock()
 
-	teamSvc := services.NewTeamService(teamRepo, userRepo, tm)
-	userSvc := services.NewUserService(userRepo, prRepo, tm)
-	prSvc := services.NewPRService(prRepo, userRepo, teamRepo, tm)
+	// Регистрируем стратегии выбора ревьюеров
+	selectors := services.NewSelectorRegistry(cfg.ReviewerConfig.DefaultStrategy, cfg.ReviewerConfig.TeamStrategies)
+	selectors.Register(services.StrategyRandom, services.NewRandomSelector())
+	selectors.Register(services.StrategyLeastLoaded, services.NewLeastLoadedSelector())
+	selectors.Register(services.StrategyRoundRobin, services.NewRoundRobinSelector(teamRepo))
+	selectors.Register(services.StrategyKnowledge, services.NewKnowledgeSpreadingSelector(prRepo, clock, cfg.ReviewerConfig.HistoryWindow))
+	if err := selectors.Validate(); err != nil {
+		logger.Fatal("invalid reviewer strategy config", zap.Error(err))
+	}
+
+	prSvc := services.NewPRService(prRepo, userRepo, teamRepo, codeOwnerRepo, ruleRepo, absenceRepo, declineRepo, revisionRepo, eventRepo, selectors, clock, tm)
+
+	teamSvc := services.NewTeamService(teamRepo, userRepo, codeOwnerRepo, absenceRepo, &prSvc, clock, tm)
+	userSvc := services.NewUserService(userRepo, prRepo, &prSvc, tm)
+	ruleSvc := services.NewReviewerRuleService(ruleRepo, userRepo, tm)
+	absenceSvc := services.NewAbsenceService(absenceRepo, userRepo, &prSvc, tm)
+
+	// Периодически добираем ревьюеров: отсутствия заканчиваются без явного вызова API
+	if cfg.ReviewerConfig.TopUpInterval > 0 {
+		go runTopUp(ctx, &prSvc, cfg.ReviewerConfig.TopUpInterval, logger)
+	}
 
-	srv := handlers.NewServer(cfg, &teamSvc, &userSvc, &prSvc, logger)
+	srv := handlers.NewServer(cfg, &teamSvc, &userSvc, &prSvc, &ruleSvc, &absenceSvc, logger)
 
 	if err := srv.Run(ctx); err != nil {
 		logger.Error("server stopped with error", zap.Error(err))
@@ -49,3 +77,23 @@ func main() {
 		logger.Info("server stopped gracefully")
 	}
 }
+
+func runTopUp(ctx context.Context, prSvc *services.PRService, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			topUps, err := prSvc.TopUpAll(ctx)
+			if err != nil {
+				logger.Error("reviewer top-up failed", zap.Error(err))
+			}
+			for _, t := range topUps {
+				logger.Info("pull request topped up", zap.String("pull_request_id", t.PullRequestId), zap.Int("added", len(t.Added)))
+			}
+		}
+	}
+}
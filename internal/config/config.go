@@ -1,14 +1,18 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	ServerPort string
-	DbConfig   DbConfig
+	ServerPort     string
+	DbConfig       DbConfig
+	ReviewerConfig ReviewerConfig
 }
 
 type DbConfig struct {
@@ -19,6 +23,15 @@ type DbConfig struct {
 	Password string
 }
 
+type ReviewerConfig struct {
+	DefaultStrategy string
+	TeamStrategies  map[string]string
+	// HistoryWindow — за какой период стратегия knowledge_spreading смотрит на прошлые ревью автора
+	HistoryWindow time.Duration
+	// TopUpInterval — период добора ревьюеров в недоукомплектованные PR; 0 отключает фоновый добор
+	TopUpInterval time.Duration
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -26,12 +39,35 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// parseTeamStrategies разбирает строку вида "backend=least_loaded,qa=random".
+func parseTeamStrategies(value string) map[string]string {
+	strategies := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		team, strategy, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || team == "" || strategy == "" {
+			continue
+		}
+		strategies[strings.TrimSpace(team)] = strings.TrimSpace(strategy)
+	}
+	return strategies
+}
+
 func LoadConfig() (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
 		return nil, err
 	}
 
+	historyWindow, err := time.ParseDuration(getEnv("REVIEWER_HISTORY_WINDOW", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEWER_HISTORY_WINDOW: %w", err)
+	}
+
+	topUpInterval, err := time.ParseDuration(getEnv("REVIEWER_TOPUP_INTERVAL", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEWER_TOPUP_INTERVAL: %w", err)
+	}
+
 	return &Config{
 		ServerPort: getEnv("PORT", "8080"),
 		DbConfig: DbConfig{
@@ -41,5 +77,11 @@ func LoadConfig() (*Config, error) {
 			User:     getEnv("POSTGRES_USER", "postgres"),
 			Password: getEnv("POSTGRES_PASSWORD", "postgres"),
 		},
+		ReviewerConfig: ReviewerConfig{
+			DefaultStrategy: getEnv("REVIEWER_STRATEGY", "random"),
+			TeamStrategies:  parseTeamStrategies(getEnv("REVIEWER_TEAM_STRATEGIES", "")),
+			HistoryWindow:   historyWindow,
+			TopUpInterval:   topUpInterval,
+		},
 	}, nil
 }
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Absence — период отсутствия пользователя [StartsAt, EndsAt), в который он не получает ревью.
+type Absence struct {
+	Id       int64
+	UserId   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
+func (a Absence) ActiveAt(t time.Time) bool {
+	return !t.Before(a.StartsAt) && t.Before(a.EndsAt)
+}
+
+// AbsentAt сообщает, отсутствует ли пользователь в момент t хотя бы по одному из периодов.
+func AbsentAt(absences []Absence, t time.Time) bool {
+	for _, a := range absences {
+		if a.ActiveAt(t) {
+			return true
+		}
+	}
+	return false
+}
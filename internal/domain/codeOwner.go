@@ -0,0 +1,24 @@
+package domain
+
+import "strings"
+
+const teamOwnerPrefix = "team/"
+
+// CodeOwnerRule — строка CODEOWNERS: шаблон пути и владельцы в виде "@user_id" или "@team/team_name".
+type CodeOwnerRule struct {
+	Pattern string
+	Owners  []string
+}
+
+type CodeOwner struct {
+	UserId   string
+	TeamName string
+}
+
+func ParseCodeOwner(token string) CodeOwner {
+	name := strings.TrimPrefix(token, "@")
+	if team, ok := strings.CutPrefix(name, teamOwnerPrefix); ok {
+		return CodeOwner{TeamName: team}
+	}
+	return CodeOwner{UserId: name}
+}
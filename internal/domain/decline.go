@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+type DeclineReason string
+
+const (
+	DeclineConflict   DeclineReason = "conflict"
+	DeclineNoContext  DeclineReason = "no_context"
+	DeclineOverloaded DeclineReason = "overloaded"
+)
+
+type PRDecline struct {
+	Id      string
+	UserId  string
+	Reason  DeclineReason
+	Comment string
+}
+
+// ReviewDecline — отказ ревьюера от PR; отказавшийся больше не назначается на этот PR.
+type ReviewDecline struct {
+	PullRequestId string
+	ReviewerId    string
+	TeamName      string
+	Reason        DeclineReason
+	Comment       string
+	DeclinedAt    time.Time
+}
@@ -0,0 +1,86 @@
+package domain
+
+import "slices"
+
+// PRDependency — ребро стека: ChildId нельзя сливать, пока не слит ParentId.
+type PRDependency struct {
+	ChildId  string
+	ParentId string
+}
+
+type PRGraphNode struct {
+	Id     string
+	Name   string
+	Status PullRequestStatus
+}
+
+// PRDependencyGraph — стек вокруг PR Id: все его предки и потомки.
+type PRDependencyGraph struct {
+	Id    string
+	Nodes []PRGraphNode
+	Edges []PRDependency
+}
+
+// BlocksChildren сообщает, мешает ли PR в таком состоянии слиянию зависимых от него.
+// Закрытый PR заброшен и стек не держит.
+func (s PullRequestStatus) BlocksChildren() bool {
+	return s == StatusOpen || s == StatusDraft
+}
+
+func (g PRDependencyGraph) ParentIds(id string) []string {
+	parents := make([]string, 0)
+	for _, e := range g.Edges {
+		if e.ChildId == id {
+			parents = append(parents, e.ParentId)
+		}
+	}
+	slices.Sort(parents)
+	return parents
+}
+
+// Ancestors возвращает всех предков id без повторов.
+func (g PRDependencyGraph) Ancestors(id string) []string {
+	seen := make(map[string]bool)
+	var walk func(string)
+	walk = func(cur string) {
+		for _, p := range g.ParentIds(cur) {
+			if !seen[p] {
+				seen[p] = true
+				walk(p)
+			}
+		}
+	}
+	walk(id)
+
+	ancestors := make([]string, 0, len(seen))
+	for p := range seen {
+		ancestors = append(ancestors, p)
+	}
+	slices.Sort(ancestors)
+	return ancestors
+}
+
+// BlockingChain возвращает неслитых предков, мешающих слить id, в порядке слияния: сначала корни стека.
+// Обход идёт только через блокирующие PR: предок уже слитого родителя id не держит.
+func (g PRDependencyGraph) BlockingChain(id string) []string {
+	statuses := make(map[string]PullRequestStatus, len(g.Nodes))
+	for _, n := range g.Nodes {
+		statuses[n.Id] = n.Status
+	}
+
+	chain := make([]string, 0)
+	visited := make(map[string]bool)
+	var walk func(string)
+	walk = func(cur string) {
+		for _, p := range g.ParentIds(cur) {
+			if visited[p] || !statuses[p].BlocksChildren() {
+				continue
+			}
+			visited[p] = true
+			walk(p)
+			chain = append(chain, p)
+		}
+	}
+	walk(id)
+	return chain
+}
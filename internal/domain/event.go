@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+type PREventKind string
+
+const (
+	EventCreated          PREventKind = "created"
+	EventReviewerAssigned PREventKind = "reviewer_assigned"
+	EventReviewerRemoved  PREventKind = "reviewer_removed"
+	EventReassigned       PREventKind = "reassigned"
+	EventReviewDeclined   PREventKind = "review_declined"
+	EventReviewSubmitted  PREventKind = "review_submitted"
+	EventUpdated          PREventKind = "updated"
+	EventAuthorChanged    PREventKind = "author_changed"
+	EventReady            PREventKind = "ready"
+	EventClosed           PREventKind = "closed"
+	EventReopened         PREventKind = "reopened"
+	EventRevisionPushed   PREventKind = "revision_pushed"
+	EventMerged           PREventKind = "merged"
+)
+
+// PREvent — запись ленты событий PR. Лента только дописывается и пишется в той же транзакции, что и изменение.
+type PREvent struct {
+	Id            int64
+	PullRequestId string
+	Kind          PREventKind
+	// ActorId — кто совершил действие; пусто для автоматических изменений
+	ActorId string
+	// FromUserId и ToUserId — прежний и новый пользователь для назначений, переназначений и смены автора
+	FromUserId string
+	ToUserId   string
+	// Details — вид решения ревью, SHA ревизии и т.п.
+	Details   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "slices"
+
+// MergeConditionKind — условие политики слияния команды.
+type MergeConditionKind string
+
+const (
+	ConditionApprovals        MergeConditionKind = "approvals"
+	ConditionChangesRequested MergeConditionKind = "no_changes_requested"
+	ConditionParentsMerged    MergeConditionKind = "parents_merged"
+)
+
+// MergeCondition описывает невыполненное условие: для approvals — сколько одобрений нужно и сколько есть,
+// для no_changes_requested — кто из ревьюеров запросил изменения,
+// для parents_merged — цепочка неслитых родительских PR в порядке слияния.
+type MergeCondition struct {
+	Kind           MergeConditionKind
+	Required       int
+	Actual         int
+	ReviewerIds    []string
+	PullRequestIds []string
+}
+
+// UnmetMergeConditions проверяет PR по политике команды. Учитываются только последние решения
+// текущих ревьюеров, устаревшие решения и одобрение автора не засчитываются.
+func (t Team) UnmetMergeConditions(authorId string, reviewers []AssignedReviewer) []MergeCondition {
+	approvals := 0
+	changesRequested := make([]string, 0)
+	for _, r := range reviewers {
+		if r.Decision == nil || r.Decision.Stale || r.Id == authorId {
+			continue
+		}
+		switch r.Decision.Decision {
+		case DecisionApproved:
+			approvals++
+		case DecisionChangesRequested:
+			changesRequested = append(changesRequested, r.Id)
+		}
+	}
+
+	unmet := make([]MergeCondition, 0)
+	if approvals < t.RequiredApprovals {
+		unmet = append(unmet, MergeCondition{Kind: ConditionApprovals, Required: t.RequiredApprovals, Actual: approvals})
+	}
+	if len(changesRequested) > 0 {
+		slices.Sort(changesRequested)
+		unmet = append(unmet, MergeCondition{Kind: ConditionChangesRequested, ReviewerIds: changesRequested})
+	}
+	return unmet
+}
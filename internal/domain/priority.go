@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+type PullRequestPriority string
+
+const (
+	PriorityLow    PullRequestPriority = "low"
+	PriorityNormal PullRequestPriority = "normal"
+	PriorityHigh   PullRequestPriority = "high"
+	PriorityUrgent PullRequestPriority = "urgent"
+)
+
+// Rank — чем больше, тем срочнее; неизвестный приоритет считается обычным.
+func (p PullRequestPriority) Rank() int {
+	switch p {
+	case PriorityLow:
+		return 0
+	case PriorityHigh:
+		return 2
+	case PriorityUrgent:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// NormalizeLabels обрезает пробелы, убирает пустые метки и повторы, сохраняя порядок.
+func NormalizeLabels(labels []string) []string {
+	res := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = strings.TrimSpace(l)
+		if l != "" && !slices.Contains(res, l) {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+// SortReviewQueue упорядочивает PR для ревьюера: сначала срочные, при равном приоритете — более старые.
+func SortReviewQueue(prs []PullRequestReviewRead) {
+	slices.SortStableFunc(prs, func(a, b PullRequestReviewRead) int {
+		if a.Priority.Rank() != b.Priority.Rank() {
+			return b.Priority.Rank() - a.Priority.Rank()
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+}
@@ -1,18 +1,86 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type PullRequestStatus string
 
 const (
+	StatusDraft  PullRequestStatus = "DRAFT"
 	StatusOpen   PullRequestStatus = "OPEN"
 	StatusMerged PullRequestStatus = "MERGED"
+	StatusClosed PullRequestStatus = "CLOSED"
 )
 
+// statusTransitions — допустимые переходы: черновик без ревьюеров становится открытым или закрывается,
+// открытый сливается или закрывается, закрытый можно переоткрыть.
+var statusTransitions = map[PullRequestStatus][]PullRequestStatus{
+	StatusDraft:  {StatusOpen, StatusClosed},
+	StatusOpen:   {StatusMerged, StatusClosed},
+	StatusClosed: {StatusOpen},
+}
+
+func (s PullRequestStatus) CanTransitionTo(next PullRequestStatus) bool {
+	return slices.Contains(statusTransitions[s], next)
+}
+
 type PullRequestCreate struct {
-	Id       string
-	Name     string
-	AuthorId string
+	Id           string
+	Name         string
+	AuthorId     string
+	ChangedFiles []string
+	// ReviewerIds — явно выбранные ревьюеры; автоматический выбор добирает оставшиеся места
+	ReviewerIds []string
+	// Draft создаёт черновик: ревьюеры назначаются только после перевода в OPEN
+	Draft       bool
+	Description string
+	ExternalURL string
+	Labels      []string
+	Priority    PullRequestPriority
+	// ParentIds — PR, которые должны быть слиты раньше этого
+	ParentIds []string
+}
+
+// PRUpdate — частичное изменение PR: nil-поля не меняются.
+// Смена AuthorId передаёт авторство, например когда автор уходит из компании.
+type PRUpdate struct {
+	Id          string
+	Name        *string
+	Description *string
+	ExternalURL *string
+	AuthorId    *string
+	Labels      *[]string
+	Priority    *PullRequestPriority
+}
+
+// HasDetails сообщает, меняет ли обновление что-то кроме автора.
+func (u PRUpdate) HasDetails() bool {
+	return u.Name != nil || u.Description != nil || u.ExternalURL != nil || u.Labels != nil || u.Priority != nil
+}
+
+// PRStatusChange — перевод PR в другое состояние. ChangedFiles учитываются при назначении
+// ревьюеров, когда PR становится открытым.
+type PRStatusChange struct {
+	Id           string
+	ChangedFiles []string
+}
+
+type AssignedReviewer struct {
+	Id          string
+	External    bool
+	MatchedRule string
+	// Decision — последнее решение ревьюера, nil пока он ничего не отправил
+	Decision *ReviewDecision
+}
+
+func ReviewerIds(reviewers []AssignedReviewer) []string {
+	ids := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		ids = append(ids, r.Id)
+	}
+	return ids
 }
 
 type PullRequestRead struct {
@@ -20,18 +88,30 @@ type PullRequestRead struct {
 	Name              string
 	AuthorId          string
 	Status            PullRequestStatus
+	Description       string
+	ExternalURL       string
+	Labels            []string
+	Priority          PullRequestPriority
 	AssignReviewerIds []string
+	Reviewers         []AssignedReviewer
 }
 
 type PullRequestReviewRead struct {
-	Id       string
-	Name     string
-	AuthorId string
-	Status   PullRequestStatus
+	Id        string
+	Name      string
+	AuthorId  string
+	Status    PullRequestStatus
+	Labels    []string
+	Priority  PullRequestPriority
+	CreatedAt time.Time
+	Decision  *ReviewDecision
 }
 
 type PRMerge struct {
 	Id string
+	// Force позволяет администратору UserId слить PR в обход политики команды
+	Force  bool
+	UserId string
 }
 
 type PRMergeRead struct {
@@ -39,16 +119,64 @@ type PRMergeRead struct {
 	Name              string
 	AuthorId          string
 	Status            PullRequestStatus
+	Labels            []string
+	Priority          PullRequestPriority
 	AssignReviewerIds []string
+	Reviewers         []AssignedReviewer
 	MergedAt          *time.Time
+	ForcedBy          string
+	Unmet             []MergeCondition
 }
 
 type PRReassign struct {
 	Id        string
 	OldUserId string
+	// NewUserId — явно запрошенная замена; пустое значение означает автоматический выбор
+	NewUserId string
+}
+
+type PRReviewerChange struct {
+	Id     string
+	UserId string
 }
 
 type PrReassignRead struct {
 	PullRequest PullRequestRead
 	ReplacedId  string
+	Requested   bool
+}
+
+type ExclusionReason string
+
+const (
+	ExclusionAuthor           ExclusionReason = "author"
+	ExclusionInactive         ExclusionReason = "inactive"
+	ExclusionAbsent           ExclusionReason = "absent"
+	ExclusionAlreadyReviewing ExclusionReason = "already_reviewing"
+	ExclusionDeclined         ExclusionReason = "declined"
+	ExclusionAtCapacity       ExclusionReason = "at_capacity"
+	ExclusionByRule           ExclusionReason = "excluded_by_rule"
+	ExclusionNotSelected      ExclusionReason = "not_selected"
+	ExclusionUnavailable      ExclusionReason = "unavailable"
+)
+
+type ExcludedMember struct {
+	UserId   string
+	TeamName string
+	Reason   ExclusionReason
+}
+
+type ReviewerPreview struct {
+	Candidates      []ReviewerCandidate
+	Reviewers       []AssignedReviewer
+	Excluded        []ExcludedMember
+	MinReviewersMet bool
+	// Now — момент расчёта, от него считается местное время кандидатов
+	Now time.Time
+}
+
+// TopUp — ревьюеры, добавленные на свободные места открытого PR.
+type TopUp struct {
+	PullRequestId string
+	Added         []AssignedReviewer
 }
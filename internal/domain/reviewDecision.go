@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+type ReviewDecisionKind string
+
+const (
+	DecisionApproved         ReviewDecisionKind = "APPROVED"
+	DecisionChangesRequested ReviewDecisionKind = "CHANGES_REQUESTED"
+	DecisionCommented        ReviewDecisionKind = "COMMENTED"
+)
+
+// ReviewDecision — решение ревьюера по PR. История сохраняется целиком, действующим считается последнее решение.
+type ReviewDecision struct {
+	Id            int64
+	PullRequestId string
+	ReviewerId    string
+	Decision      ReviewDecisionKind
+	Body          string
+	SubmittedAt   time.Time
+	// RevisionId и CommitSHA — ревизия, на которой принято решение; nil, если ревизии не записывались
+	RevisionId *int64
+	CommitSHA  string
+	// Stale — решение устарело после новой ревизии и не учитывается политикой слияния
+	Stale bool
+}
+
+// StaleReviewPolicy определяет, какие решения устаревают, когда в PR приходит новая ревизия.
+type StaleReviewPolicy string
+
+const (
+	StaleDismissAll       StaleReviewPolicy = "dismiss_all"
+	StaleDismissApprovals StaleReviewPolicy = "dismiss_approvals"
+	StaleKeep             StaleReviewPolicy = "keep"
+)
+
+// DismissedKinds возвращает виды решений, которые политика делает устаревшими.
+func (p StaleReviewPolicy) DismissedKinds() []ReviewDecisionKind {
+	switch p {
+	case StaleDismissAll:
+		return []ReviewDecisionKind{DecisionApproved, DecisionChangesRequested, DecisionCommented}
+	case StaleDismissApprovals:
+		return []ReviewDecisionKind{DecisionApproved}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+type ReviewerRuleKind string
+
+const (
+	RuleExclude ReviewerRuleKind = "exclude"
+	RulePrefer  ReviewerRuleKind = "prefer"
+)
+
+// ReviewerRule задаёт отношение ревьюера к автору: запрет (exclude) или предпочтение (prefer).
+type ReviewerRule struct {
+	ReviewerId string
+	AuthorId   string
+	Kind       ReviewerRuleKind
+}
+
+// ExcludesReviewer сообщает, запрещено ли правилами ревьюеру смотреть PR автора.
+func ExcludesReviewer(rules []ReviewerRule, reviewerId string, authorId string) bool {
+	for _, r := range rules {
+		if r.ReviewerId == reviewerId && r.AuthorId == authorId && r.Kind == RuleExclude {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Revision — запушенный в PR коммит.
+type Revision struct {
+	Id            int64
+	PullRequestId string
+	CommitSHA     string
+	PushedAt      time.Time
+}
+
+// RevisionPush — результат записи ревизии: сама ревизия и решения, ставшие устаревшими.
+type RevisionPush struct {
+	Revision    Revision
+	Stale       []ReviewDecision
+	PullRequest PullRequestRead
+}
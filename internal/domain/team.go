@@ -1,14 +1,75 @@
 package domain
 
+import "slices"
+
+const DefaultLevel = 1
+
 type TeamMember struct {
 	ID       string
 	Username string
 	IsActive bool
+	// Level и IsAdmin nil при добавлении в команду — оставить сохранённые значения
+	// (для нового пользователя DefaultLevel и false)
+	Level    *int
+	IsAdmin  *bool
+	Absences []Absence
 }
 
+const DefaultReviewersCount = 2
+
+// CapacityPolicy определяет, что делать, если все кандидаты достигли лимита открытых ревью.
+type CapacityPolicy string
+
+const (
+	CapacityOverAssign CapacityPolicy = "over_assign"
+	CapacityLeaveEmpty CapacityPolicy = "leave_empty"
+	CapacityReject     CapacityPolicy = "reject"
+)
+
 type Team struct {
-	Name    string
-	Members []TeamMember
+	Name           string
+	ReviewersCount int
+	MinReviewers   int
+	CapacityPolicy CapacityPolicy
+	FallbackTeams  []string
+	SeniorLevel    *int
+	// RequiredApprovals — сколько одобрений нужно для слияния PR автора из команды
+	RequiredApprovals int
+	// StaleReviewPolicy — что происходит с решениями ревьюеров при новой ревизии PR
+	StaleReviewPolicy StaleReviewPolicy
+	Members           []TeamMember
+}
+
+// RequiresSenior сообщает, нужен ли по политике команды хотя бы один ревьюер уровня SeniorLevel.
+func (t Team) RequiresSenior() bool {
+	return t.SeniorLevel != nil
+}
+
+func (t Team) IsSenior(level int) bool {
+	return t.SeniorLevel != nil && level >= *t.SeniorLevel
+}
+
+// ReassessReviewers пересчитывает признак External ревьюеров для автора из команды t (teamOf — команда
+// каждого ревьюера) и возвращает id тех, кто больше не может ревьюить PR: сам автор, запрещённые
+// правилом и пользователи вне команды автора и её резервных команд.
+func (t Team) ReassessReviewers(authorId string, reviewers []AssignedReviewer, teamOf map[string]string, rules []ReviewerRule) ([]AssignedReviewer, []string) {
+	reassessed := make([]AssignedReviewer, 0, len(reviewers))
+	invalid := make([]string, 0)
+	for _, r := range reviewers {
+		teamName := teamOf[r.Id]
+		r.External = teamName != t.Name
+		reassessed = append(reassessed, r)
+
+		if r.Id == authorId || ExcludesReviewer(rules, r.Id, authorId) || (r.External && !slices.Contains(t.FallbackTeams, teamName)) {
+			invalid = append(invalid, r.Id)
+		}
+	}
+	return reassessed, invalid
+}
+
+type RotationEntry struct {
+	UserId   string
+	Position int
 }
 
 type TeamMemberCreate struct {
@@ -6,13 +6,36 @@ type UserShort struct {
 }
 
 type User struct {
-	Id       string
-	Username string
-	TeamName string
-	IsActive bool
+	Id             string
+	Username       string
+	TeamName       string
+	IsActive       bool
+	MaxOpenReviews *int
+	Level          int
+	IsAdmin        bool
+	Schedule       WorkSchedule
+}
+
+type UserCapacity struct {
+	Id             string
+	MaxOpenReviews *int
 }
 
 type UserReview struct {
 	Id           string
 	PullRequests []PullRequestReviewRead
 }
+
+type ReviewerCandidate struct {
+	Id             string
+	TeamName       string
+	OpenReviews    int
+	MaxOpenReviews *int
+	Level          int
+	Schedule       WorkSchedule
+	Preferred      bool
+}
+
+func (c ReviewerCandidate) AtCapacity() bool {
+	return c.MaxOpenReviews != nil && c.OpenReviews >= *c.MaxOpenReviews
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+const (
+	DefaultTimeZone = "UTC"
+	minutesPerDay   = 24 * 60
+)
+
+// WorkSchedule — часовой пояс и рабочие часы пользователя.
+// WorkStart и WorkEnd задаются в минутах от полуночи по местному времени;
+// если WorkEnd меньше WorkStart, смена переходит через полночь. Без часов пользователь считается доступным всегда.
+type WorkSchedule struct {
+	TimeZone  string
+	WorkStart *int
+	WorkEnd   *int
+}
+
+func (w WorkSchedule) Location() *time.Location {
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil || w.TimeZone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+func (w WorkSchedule) LocalTime(now time.Time) time.Time {
+	return now.In(w.Location())
+}
+
+func (w WorkSchedule) HasHours() bool {
+	return w.WorkStart != nil && w.WorkEnd != nil
+}
+
+// UntilWork возвращает, сколько осталось до начала рабочего времени; ноль — пользователь уже работает.
+func (w WorkSchedule) UntilWork(now time.Time) time.Duration {
+	if !w.HasHours() {
+		return 0
+	}
+
+	local := w.LocalTime(now)
+	minute := local.Hour()*60 + local.Minute()
+	start, end := *w.WorkStart, *w.WorkEnd
+
+	working := minute >= start && minute < end
+	if start > end {
+		working = minute >= start || minute < end
+	}
+	if working {
+		return 0
+	}
+
+	return time.Duration((start-minute+minutesPerDay)%minutesPerDay) * time.Minute
+}
+
+func (w WorkSchedule) InWorkingHours(now time.Time) bool {
+	return w.UntilWork(now) == 0
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type AbsenceRequest struct {
+	UserId   string    `json:"user_id" binding:"required"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason"`
+}
+
+type AbsenceDeleteRequest struct {
+	Id int64 `json:"absence_id" binding:"required"`
+}
+
+type AbsenceDTO struct {
+	Id       int64     `json:"absence_id"`
+	UserId   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type UserAbsencesResponse struct {
+	Id       string       `json:"user_id"`
+	Absences []AbsenceDTO `json:"absences"`
+}
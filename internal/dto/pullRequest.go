@@ -3,53 +3,236 @@ package dto
 import "time"
 
 type PRCreateRequest struct {
-	Id       string `json:"pull_request_id" binding:"required"`
-	Name     string `json:"pull_request_name" binding:"required"`
-	AuthorId string `json:"author_id" binding:"required"`
+	Id           string   `json:"pull_request_id" binding:"required"`
+	Name         string   `json:"pull_request_name" binding:"required"`
+	AuthorId     string   `json:"author_id" binding:"required"`
+	ChangedFiles []string `json:"changed_files"`
+	ReviewerIds  []string `json:"reviewer_ids" binding:"omitempty,dive,required"`
+	Draft        bool     `json:"draft"`
+	Description  string   `json:"description"`
+	ExternalURL  string   `json:"external_url" binding:"omitempty,http_url"`
+	Labels       []string `json:"labels" binding:"omitempty,dive,max=50"`
+	Priority     string   `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	ParentIds    []string `json:"parent_ids" binding:"omitempty,dive,required"`
+}
+
+// PRUpdateRequest — поля, которых нет в запросе, не меняются; пустой external_url убирает ссылку.
+type PRUpdateRequest struct {
+	Id          string    `json:"pull_request_id" binding:"required"`
+	Name        *string   `json:"pull_request_name" binding:"omitempty,min=1"`
+	Description *string   `json:"description"`
+	ExternalURL *string   `json:"external_url" binding:"omitempty,len=0|http_url"`
+	AuthorId    *string   `json:"author_id" binding:"omitempty,min=1"`
+	Labels      *[]string `json:"labels" binding:"omitempty,dive,max=50"`
+	Priority    *string   `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+}
+
+type PRStatusChangeRequest struct {
+	Id           string   `json:"pull_request_id" binding:"required"`
+	ChangedFiles []string `json:"changed_files"`
+}
+
+type ReviewerDTO struct {
+	Id          string             `json:"user_id"`
+	External    bool               `json:"is_external"`
+	MatchedRule string             `json:"matched_rule,omitempty"`
+	Decision    *ReviewDecisionDTO `json:"decision,omitempty"`
 }
 
 type PRCreateResponse struct {
-	Id                string   `json:"pull_request_id"`
-	Name              string   `json:"pull_request_name"`
-	AuthorId          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignReviewerIds []string `json:"assign_reviewer"`
+	Id                string        `json:"pull_request_id"`
+	Name              string        `json:"pull_request_name"`
+	AuthorId          string        `json:"author_id"`
+	Status            string        `json:"status"`
+	Description       string        `json:"description,omitempty"`
+	ExternalURL       string        `json:"external_url,omitempty"`
+	Labels            []string      `json:"labels"`
+	Priority          string        `json:"priority"`
+	AssignReviewerIds []string      `json:"assign_reviewer"`
+	Reviewers         []ReviewerDTO `json:"reviewers"`
 }
 
 type PRReadResponse struct {
-	Id       string `json:"pull_request_id"`
-	Name     string `json:"pull_request_name"`
-	AuthorId string `json:"author_id"`
-	Status   string `json:"status"`
+	Id       string             `json:"pull_request_id"`
+	Name     string             `json:"pull_request_name"`
+	AuthorId string             `json:"author_id"`
+	Status   string             `json:"status"`
+	Labels   []string           `json:"labels"`
+	Priority string             `json:"priority"`
+	Decision *ReviewDecisionDTO `json:"decision,omitempty"`
 }
 
 type PRMergeRequest struct {
-	Id string `json:"pull_request_id" binding:"required"`
+	Id     string `json:"pull_request_id" binding:"required"`
+	Force  bool   `json:"force"`
+	UserId string `json:"user_id" binding:"required_if=Force true"`
+}
+
+type MergeConditionDTO struct {
+	Condition      string   `json:"condition"`
+	Required       int      `json:"required,omitempty"`
+	Actual         int      `json:"actual,omitempty"`
+	ReviewerIds    []string `json:"reviewer_ids,omitempty"`
+	PullRequestIds []string `json:"pull_request_ids,omitempty"`
+}
+
+type PRGraphNodeDTO struct {
+	Id        string   `json:"pull_request_id"`
+	Name      string   `json:"pull_request_name"`
+	Status    string   `json:"status"`
+	ParentIds []string `json:"parent_ids"`
+}
+
+type PRDependencyGraphResponse struct {
+	Id            string           `json:"pull_request_id"`
+	Nodes         []PRGraphNodeDTO `json:"nodes"`
+	BlockingChain []string         `json:"blocking_chain"`
 }
 
 type PRMergeResponse struct {
-	Id                string     `json:"pull_request_id"`
-	Name              string     `json:"pull_request_name"`
-	AuthorId          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignReviewerIds []string   `json:"assigned_reviewers"`
-	MergedAt          *time.Time `json:"mergedAt"`
+	Id                string        `json:"pull_request_id"`
+	Name              string        `json:"pull_request_name"`
+	AuthorId          string        `json:"author_id"`
+	Status            string        `json:"status"`
+	Labels            []string      `json:"labels"`
+	Priority          string        `json:"priority"`
+	AssignReviewerIds []string      `json:"assigned_reviewers"`
+	Reviewers         []ReviewerDTO `json:"reviewers"`
+	MergedAt          *time.Time    `json:"mergedAt"`
+	// ForcedBy и Unmet заполняются, только если администратор слил PR в обход политики
+	ForcedBy string              `json:"forced_by,omitempty"`
+	Unmet    []MergeConditionDTO `json:"unmet_conditions,omitempty"`
 }
 
 type PRReassignRequest struct {
 	Id        string `json:"pull_request_id" binding:"required"`
 	OldUserId string `json:"old_user_id" binding:"required"`
+	NewUserId string `json:"new_user_id"`
+}
+
+type PRReviewerChangeRequest struct {
+	Id     string `json:"pull_request_id" binding:"required"`
+	UserId string `json:"user_id" binding:"required"`
+}
+
+type TopUpDTO struct {
+	Id    string        `json:"pull_request_id"`
+	Added []ReviewerDTO `json:"added"`
+}
+
+type PRDeclineRequest struct {
+	Id      string `json:"pull_request_id" binding:"required"`
+	UserId  string `json:"user_id" binding:"required"`
+	Reason  string `json:"reason" binding:"required,oneof=conflict no_context overloaded"`
+	Comment string `json:"comment"`
+}
+
+type ReviewDeclineDTO struct {
+	PullRequestId string    `json:"pull_request_id"`
+	ReviewerId    string    `json:"user_id"`
+	TeamName      string    `json:"team_name"`
+	Reason        string    `json:"reason"`
+	Comment       string    `json:"comment"`
+	DeclinedAt    time.Time `json:"declined_at"`
+}
+
+type DeclinesResponse struct {
+	Declines []ReviewDeclineDTO `json:"declines"`
+}
+
+type ReviewSubmitRequest struct {
+	Id       string `json:"pull_request_id" binding:"required"`
+	UserId   string `json:"user_id" binding:"required"`
+	Decision string `json:"decision" binding:"required,oneof=APPROVED CHANGES_REQUESTED COMMENTED"`
+	Body     string `json:"body"`
+}
+
+type ReviewDecisionDTO struct {
+	PullRequestId string    `json:"pull_request_id"`
+	ReviewerId    string    `json:"user_id"`
+	Decision      string    `json:"decision"`
+	Body          string    `json:"body"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+	RevisionId    *int64    `json:"revision_id,omitempty"`
+	CommitSHA     string    `json:"commit_sha,omitempty"`
+	Stale         bool      `json:"stale"`
+}
+
+type RevisionPushRequest struct {
+	Id        string     `json:"pull_request_id" binding:"required"`
+	CommitSHA string     `json:"commit_sha" binding:"required,hexadecimal,min=7,max=64"`
+	PushedAt  *time.Time `json:"pushed_at"`
+}
+
+type RevisionDTO struct {
+	Id            int64     `json:"revision_id"`
+	PullRequestId string    `json:"pull_request_id"`
+	CommitSHA     string    `json:"commit_sha"`
+	PushedAt      time.Time `json:"pushed_at"`
+}
+
+type PREventDTO struct {
+	Id         int64     `json:"event_id"`
+	Kind       string    `json:"kind"`
+	ActorId    string    `json:"actor_id,omitempty"`
+	FromUserId string    `json:"from_user_id,omitempty"`
+	ToUserId   string    `json:"to_user_id,omitempty"`
+	Details    string    `json:"details,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type TimelineResponse struct {
+	Id     string       `json:"pull_request_id"`
+	Events []PREventDTO `json:"events"`
+}
+
+type RevisionsResponse struct {
+	Id        string        `json:"pull_request_id"`
+	Revisions []RevisionDTO `json:"revisions"`
+}
+
+type ReviewHistoryResponse struct {
+	Id      string              `json:"pull_request_id"`
+	Reviews []ReviewDecisionDTO `json:"reviews"`
 }
 
 type ReassignResponse struct {
-	Id                string   `json:"pull_request_id"`
-	Name              string   `json:"pull_request_name"`
-	AuthorId          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignReviewerIds []string `json:"assign_reviewer"`
+	Id                string        `json:"pull_request_id"`
+	Name              string        `json:"pull_request_name"`
+	AuthorId          string        `json:"author_id"`
+	Status            string        `json:"status"`
+	Description       string        `json:"description,omitempty"`
+	ExternalURL       string        `json:"external_url,omitempty"`
+	Labels            []string      `json:"labels"`
+	Priority          string        `json:"priority"`
+	AssignReviewerIds []string      `json:"assign_reviewer"`
+	Reviewers         []ReviewerDTO `json:"reviewers"`
 }
 
 type PrReassignResponse struct {
 	PrRead     ReassignResponse `json:"pr"`
 	ReplacedId string           `json:"replaced_by"`
+	Requested  bool             `json:"requested"`
+}
+
+type CandidateDTO struct {
+	Id             string `json:"user_id"`
+	TeamName       string `json:"team_name"`
+	OpenReviews    int    `json:"open_reviews"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+	LocalTime      string `json:"local_time"`
+	InWorkingHours bool   `json:"in_working_hours"`
+}
+
+type ExcludedMemberDTO struct {
+	Id       string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	Reason   string `json:"reason"`
+}
+
+type PRPreviewResponse struct {
+	Candidates      []CandidateDTO      `json:"candidates"`
+	Reviewers       []ReviewerDTO       `json:"reviewers"`
+	Excluded        []ExcludedMemberDTO `json:"excluded"`
+	MinReviewersMet bool                `json:"min_reviewers_met"`
 }
@@ -0,0 +1,23 @@
+package dto
+
+type ReviewerRuleRequest struct {
+	ReviewerId string `json:"reviewer_id" binding:"required"`
+	AuthorId   string `json:"author_id" binding:"required"`
+	Kind       string `json:"kind" binding:"required,oneof=exclude prefer"`
+}
+
+type ReviewerRuleDeleteRequest struct {
+	ReviewerId string `json:"reviewer_id" binding:"required"`
+	AuthorId   string `json:"author_id" binding:"required"`
+}
+
+type ReviewerRuleResponse struct {
+	ReviewerId string `json:"reviewer_id"`
+	AuthorId   string `json:"author_id"`
+	Kind       string `json:"kind"`
+}
+
+type UserReviewerRulesResponse struct {
+	Id    string                 `json:"user_id"`
+	Rules []ReviewerRuleResponse `json:"rules"`
+}
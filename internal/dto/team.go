@@ -4,19 +4,55 @@ type TeamMemberDTO struct {
 	ID       string `json:"user_id" binding:"required"`
 	Username string `json:"username" binding:"required"`
 	IsActive *bool  `json:"is_active" binding:"required"`
+	Level    *int   `json:"level" binding:"omitempty,min=1"`
+	IsAdmin  *bool  `json:"is_admin"`
+	// Absences заполняется только в ответах: текущие и предстоящие отсутствия
+	Absences []AbsenceDTO `json:"absences,omitempty"`
 }
 
 type CreateTeamRequest struct {
-	Name    string          `json:"team_name" binding:"required"`
-	Members []TeamMemberDTO `json:"members" binding:"required,dive"`
+	Name           string   `json:"team_name" binding:"required"`
+	ReviewersCount *int     `json:"reviewers_count" binding:"omitempty,min=1"`
+	MinReviewers   *int     `json:"min_reviewers" binding:"omitempty,min=0"`
+	CapacityPolicy string   `json:"capacity_policy" binding:"omitempty,oneof=over_assign leave_empty reject"`
+	FallbackTeams  []string `json:"fallback_teams"`
+	SeniorLevel    *int     `json:"senior_level" binding:"omitempty,min=1"`
+	// RequiredApprovals — политика слияния: сколько одобрений нужно, по умолчанию 0
+	RequiredApprovals *int            `json:"required_approvals" binding:"omitempty,min=0"`
+	StaleReviewPolicy string          `json:"stale_review_policy" binding:"omitempty,oneof=dismiss_all dismiss_approvals keep"`
+	Members           []TeamMemberDTO `json:"members" binding:"required,dive"`
 }
 
 type CreateTeamResponse struct {
-	Name    string          `json:"team_name"`
-	Members []TeamMemberDTO `json:"members"`
+	Name              string          `json:"team_name"`
+	ReviewersCount    int             `json:"reviewers_count"`
+	MinReviewers      int             `json:"min_reviewers"`
+	CapacityPolicy    string          `json:"capacity_policy"`
+	FallbackTeams     []string        `json:"fallback_teams"`
+	SeniorLevel       *int            `json:"senior_level"`
+	RequiredApprovals int             `json:"required_approvals"`
+	StaleReviewPolicy string          `json:"stale_review_policy"`
+	Members           []TeamMemberDTO `json:"members"`
 }
 
 type GetTeamResponse struct {
-	Name    string          `json:"team_name"`
-	Members []TeamMemberDTO `json:"members"`
+	Name              string          `json:"team_name"`
+	ReviewersCount    int             `json:"reviewers_count"`
+	MinReviewers      int             `json:"min_reviewers"`
+	CapacityPolicy    string          `json:"capacity_policy"`
+	FallbackTeams     []string        `json:"fallback_teams"`
+	SeniorLevel       *int            `json:"senior_level"`
+	RequiredApprovals int             `json:"required_approvals"`
+	StaleReviewPolicy string          `json:"stale_review_policy"`
+	Members           []TeamMemberDTO `json:"members"`
+}
+
+type CodeOwnerRuleDTO struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type CodeOwnersResponse struct {
+	Name  string             `json:"team_name"`
+	Rules []CodeOwnerRuleDTO `json:"rules"`
 }
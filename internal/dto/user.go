@@ -5,11 +5,29 @@ type UserRequest struct {
 	IsActive *bool  `json:"is_active" binding:"required"`
 }
 
+type UserCapacityRequest struct {
+	Id             string `json:"user_id" binding:"required"`
+	MaxOpenReviews *int   `json:"max_open_reviews" binding:"omitempty,min=0"`
+}
+
+type UserScheduleRequest struct {
+	Id        string  `json:"user_id" binding:"required"`
+	TimeZone  string  `json:"time_zone" binding:"omitempty,timezone"`
+	WorkStart *string `json:"work_start" binding:"omitempty,datetime=15:04"`
+	WorkEnd   *string `json:"work_end" binding:"omitempty,datetime=15:04"`
+}
+
 type UserResponse struct {
-	Id       string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	Id             string  `json:"user_id"`
+	Username       string  `json:"username"`
+	TeamName       string  `json:"team_name"`
+	IsActive       bool    `json:"is_active"`
+	MaxOpenReviews *int    `json:"max_open_reviews"`
+	Level          int     `json:"level"`
+	IsAdmin        bool    `json:"is_admin"`
+	TimeZone       string  `json:"time_zone"`
+	WorkStart      *string `json:"work_start"`
+	WorkEnd        *string `json:"work_end"`
 }
 
 type UserReviewResponse struct {
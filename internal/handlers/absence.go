@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linspacestrom/InterShipAv/internal/dto"
+	"github.com/linspacestrom/InterShipAv/internal/mapper"
+	"github.com/linspacestrom/InterShipAv/internal/services"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"go.uber.org/zap"
+)
+
+type AbsenceHandler struct {
+	svc  services.AbsenceSer
+	logg *zap.Logger
+}
+
+func NewAbsenceHandlerStruct(svc services.AbsenceSer, logg *zap.Logger) *AbsenceHandler {
+	return &AbsenceHandler{svc: svc, logg: logg}
+}
+
+func (h *AbsenceHandler) AddAbsence(c *gin.Context) {
+	var absenceDTO dto.AbsenceRequest
+	if err := c.ShouldBindJSON(&absenceDTO); err != nil {
+		h.logg.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	absence, err := h.svc.Create(c.Request.Context(), mapper.DTOToAbsence(absenceDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"absence": mapper.AbsenceToDTO(absence)})
+}
+
+func (h *AbsenceHandler) DeleteAbsence(c *gin.Context) {
+	var absenceDTO dto.AbsenceDeleteRequest
+	if err := c.ShouldBindJSON(&absenceDTO); err != nil {
+		h.logg.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	topUps, err := h.svc.Delete(c.Request.Context(), absenceDTO.Id)
+	if errors.Is(err, validateError.TopUpFailed) {
+		// Отсутствие уже удалено; PR доберёт фоновый добор, если он включён
+		h.logg.Warn("absence deleted without reviewer top-up", zap.Error(err))
+		err = nil
+	}
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"topped_up": mapper.TopUpsToDTO(topUps)})
+}
+
+func (h *AbsenceHandler) GetAbsences(c *gin.Context) {
+	userId := c.Param("user_id")
+
+	absences, err := h.svc.GetByUserId(c.Request.Context(), userId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.UserAbsencesToDTO(userId, absences))
+}
+
+func (h *AbsenceHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, validateError.UserNotFound):
+		h.logg.Error("User not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.AbsenceNotFound):
+		h.logg.Error("Absence not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.InvalidAbsence):
+		h.logg.Error("Invalid absence period", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	default:
+		h.logg.Error("Internal server error", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
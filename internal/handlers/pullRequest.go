@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/linspacestrom/InterShipAv/internal/domain"
 	"github.com/linspacestrom/InterShipAv/internal/dto"
 	"github.com/linspacestrom/InterShipAv/internal/mapper"
 	"github.com/linspacestrom/InterShipAv/internal/services"
@@ -53,6 +55,12 @@ func (h *PullRequestHandler) MergePR(c *gin.Context) {
 	prDomain := mapper.DTOtoPRMerge(prDTO)
 
 	mergedPr, err := h.svc.Merge(c.Request.Context(), prDomain)
+	var blocked *validateError.MergeBlockedError
+	if errors.As(err, &blocked) {
+		h.logg.Error("Merge policy not met", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "unmet_conditions": mapper.MergeConditionsToDTO(blocked.Unmet)})
+		return
+	}
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -79,6 +87,221 @@ func (h *PullRequestHandler) ReassignPR(c *gin.Context) {
 	c.JSON(http.StatusOK, mapper.DomainToPRDTO(updatedPR))
 }
 
+func (h *PullRequestHandler) UpdatePR(c *gin.Context) {
+	var updateDTO dto.PRUpdateRequest
+	if err := c.ShouldBindJSON(&updateDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	pr, err := h.svc.Update(c.Request.Context(), mapper.DTOToPRUpdate(updateDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": mapper.DomainPullToDTO(pr)})
+}
+
+func (h *PullRequestHandler) ReadyPR(c *gin.Context) {
+	h.changeStatus(c, h.svc.Ready)
+}
+
+func (h *PullRequestHandler) ClosePR(c *gin.Context) {
+	h.changeStatus(c, h.svc.Close)
+}
+
+func (h *PullRequestHandler) ReopenPR(c *gin.Context) {
+	h.changeStatus(c, h.svc.Reopen)
+}
+
+func (h *PullRequestHandler) changeStatus(c *gin.Context, change func(context.Context, domain.PRStatusChange) (domain.PullRequestRead, error)) {
+	var changeDTO dto.PRStatusChangeRequest
+	if err := c.ShouldBindJSON(&changeDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	pr, err := change(c.Request.Context(), mapper.DTOToStatusChange(changeDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": mapper.DomainPullToDTO(pr)})
+}
+
+func (h *PullRequestHandler) AddReviewer(c *gin.Context) {
+	var changeDTO dto.PRReviewerChangeRequest
+	if err := c.ShouldBindJSON(&changeDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	pr, err := h.svc.AddReviewer(c.Request.Context(), mapper.DTOToReviewerChange(changeDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": mapper.DomainPullToDTO(pr)})
+}
+
+func (h *PullRequestHandler) RemoveReviewer(c *gin.Context) {
+	var changeDTO dto.PRReviewerChangeRequest
+	if err := c.ShouldBindJSON(&changeDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	pr, err := h.svc.RemoveReviewer(c.Request.Context(), mapper.DTOToReviewerChange(changeDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": mapper.DomainPullToDTO(pr)})
+}
+
+func (h *PullRequestHandler) DeclineReview(c *gin.Context) {
+	var declineDTO dto.PRDeclineRequest
+	if err := c.ShouldBindJSON(&declineDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	updatedPR, err := h.svc.Decline(c.Request.Context(), mapper.DTOToDecline(declineDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.DomainToPRDTO(updatedPR))
+}
+
+func (h *PullRequestHandler) GetUserDeclines(c *gin.Context) {
+	declines, err := h.svc.GetDeclinesByUserId(c.Request.Context(), c.Param("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.DeclinesToDTO(declines))
+}
+
+func (h *PullRequestHandler) GetTeamDeclines(c *gin.Context) {
+	declines, err := h.svc.GetDeclinesByTeamName(c.Request.Context(), c.Param("team_name"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.DeclinesToDTO(declines))
+}
+
+func (h *PullRequestHandler) SubmitReview(c *gin.Context) {
+	var reviewDTO dto.ReviewSubmitRequest
+	if err := c.ShouldBindJSON(&reviewDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	decision, pr, err := h.svc.SubmitReview(c.Request.Context(), mapper.DTOToReviewDecision(reviewDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"review": mapper.DecisionToDTO(&decision), "pr": mapper.DomainPullToDTO(pr)})
+}
+
+func (h *PullRequestHandler) GetReviewHistory(c *gin.Context) {
+	prId := c.Param("pull_request_id")
+	decisions, err := h.svc.GetReviewHistory(c.Request.Context(), prId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ReviewHistoryToDTO(prId, decisions))
+}
+
+func (h *PullRequestHandler) PushRevision(c *gin.Context) {
+	var revisionDTO dto.RevisionPushRequest
+	if err := c.ShouldBindJSON(&revisionDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	push, err := h.svc.PushRevision(c.Request.Context(), mapper.DTOToRevision(revisionDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"revision":      mapper.RevisionToDTO(push.Revision),
+		"stale_reviews": mapper.DecisionsToDTO(push.Stale),
+		"pr":            mapper.DomainPullToDTO(push.PullRequest),
+	})
+}
+
+func (h *PullRequestHandler) GetRevisions(c *gin.Context) {
+	prId := c.Param("pull_request_id")
+	revisions, err := h.svc.GetRevisions(c.Request.Context(), prId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.RevisionsToDTO(prId, revisions))
+}
+
+func (h *PullRequestHandler) GetTimeline(c *gin.Context) {
+	prId := c.Param("pull_request_id")
+	events, err := h.svc.GetTimeline(c.Request.Context(), prId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.TimelineToDTO(prId, events))
+}
+
+func (h *PullRequestHandler) GetDependencyGraph(c *gin.Context) {
+	graph, err := h.svc.GetDependencyGraph(c.Request.Context(), c.Param("pull_request_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.DependencyGraphToDTO(graph))
+}
+
+func (h *PullRequestHandler) PreviewReviewers(c *gin.Context) {
+	var prDTO dto.PRCreateRequest
+	if err := c.ShouldBindJSON(&prDTO); err != nil {
+		h.logg.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	preview, err := h.svc.PreviewReviewers(c.Request.Context(), mapper.DTOToPrCreate(prDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.PreviewToDTO(preview))
+}
+
 func (h *PullRequestHandler) handleError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, validateError.ErrTeamExists):
@@ -109,6 +332,30 @@ func (h *PullRequestHandler) handleError(c *gin.Context, err error) {
 		h.logg.Error("Pull request already merged", zap.Error(err))
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 
+	case errors.Is(err, validateError.PrNotOpen):
+		h.logg.Error("Pull request not open", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.InvalidStatusTransition):
+		h.logg.Error("Invalid pull request status transition", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.InvalidParent):
+		h.logg.Error("Invalid parent pull request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.DependencyCycle):
+		h.logg.Error("Pull request dependency cycle", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.RevisionExists):
+		h.logg.Error("Revision already recorded", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.DraftWithReviewers):
+		h.logg.Error("Draft pull request with reviewers", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
 	case errors.Is(err, validateError.UserNotAssignReviewer):
 		h.logg.Error("User not assigned as reviewer", zap.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -117,6 +364,54 @@ func (h *PullRequestHandler) handleError(c *gin.Context, err error) {
 		h.logg.Error("No active replacement candidate", zap.Error(err))
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 
+	case errors.Is(err, validateError.ReviewersAtCapacity):
+		h.logg.Error("All reviewer candidates at capacity", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.NotEnoughReviewers):
+		h.logg.Error("Not enough reviewers for team minimum", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ReviewerIsAuthor):
+		h.logg.Error("Author cannot be reviewer", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.AuthorInactive):
+		h.logg.Error("New author is not active", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ReviewerInactive):
+		h.logg.Error("Reviewer is not active", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ReviewerExcluded):
+		h.logg.Error("Reviewer excluded by rule", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ReviewerAbsent):
+		h.logg.Error("Reviewer is absent", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ReviewerAlreadyAssigned):
+		h.logg.Error("Reviewer already assigned", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ReviewerDeclined):
+		h.logg.Error("Reviewer declined pull request", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.SeniorReviewerRequired):
+		h.logg.Error("Senior replacement required", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ForceMergeNotAllowed):
+		h.logg.Error("Force merge by non-admin", zap.Error(err))
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.NoSeniorCandidate):
+		h.logg.Error("No senior replacement candidate", zap.Error(err))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
 	default:
 		h.logg.Error("Internal server error", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linspacestrom/InterShipAv/internal/dto"
+	"github.com/linspacestrom/InterShipAv/internal/mapper"
+	"github.com/linspacestrom/InterShipAv/internal/services"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"go.uber.org/zap"
+)
+
+type ReviewerRuleHandler struct {
+	svc  services.ReviewerRuleSer
+	logg *zap.Logger
+}
+
+func NewReviewerRuleHandlerStruct(svc services.ReviewerRuleSer, logg *zap.Logger) *ReviewerRuleHandler {
+	return &ReviewerRuleHandler{svc: svc, logg: logg}
+}
+
+func (h *ReviewerRuleHandler) SaveRule(c *gin.Context) {
+	var ruleDTO dto.ReviewerRuleRequest
+	if err := c.ShouldBindJSON(&ruleDTO); err != nil {
+		h.logg.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	rule, err := h.svc.Save(c.Request.Context(), mapper.DTOToReviewerRule(ruleDTO))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"rule": mapper.ReviewerRuleToDTO(rule)})
+}
+
+func (h *ReviewerRuleHandler) DeleteRule(c *gin.Context) {
+	var ruleDTO dto.ReviewerRuleDeleteRequest
+	if err := c.ShouldBindJSON(&ruleDTO); err != nil {
+		h.logg.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), mapper.DTODeleteToReviewerRule(ruleDTO)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ReviewerRuleHandler) GetRules(c *gin.Context) {
+	userId := c.Param("user_id")
+
+	rules, err := h.svc.GetByUserId(c.Request.Context(), userId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.UserReviewerRulesToDTO(userId, rules))
+}
+
+func (h *ReviewerRuleHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, validateError.UserNotFound):
+		h.logg.Error("User not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.ReviewerRuleNotFound):
+		h.logg.Error("Reviewer rule not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.InvalidReviewerRule):
+		h.logg.Error("Invalid reviewer rule", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	default:
+		h.logg.Error("Internal server error", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
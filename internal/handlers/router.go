@@ -13,6 +13,8 @@ func NewTeamHandler(router *gin.Engine, svc services.TeamSer, logg *zap.Logger)
 	{
 		api.POST("/add", h.CreateTeam)
 		api.GET("/get/:team_name", h.GetTeamByName)
+		api.POST("/codeOwners/:team_name", h.UploadCodeOwners)
+		api.GET("/codeOwners/:team_name", h.GetCodeOwners)
 	}
 }
 
@@ -22,10 +24,34 @@ func NewUserHandler(router *gin.Engine, svc services.UserSer, logg *zap.Logger)
 	api := router.Group("/users")
 	{
 		api.POST("/setIsActive", h.SetActive)
+		api.POST("/setMaxOpenReviews", h.SetMaxOpenReviews)
+		api.POST("/setSchedule", h.SetSchedule)
 		api.GET("/getReview/:user_id", h.GetReview)
 	}
 }
 
+func NewReviewerRuleHandler(router *gin.Engine, svc services.ReviewerRuleSer, logg *zap.Logger) {
+	h := NewReviewerRuleHandlerStruct(svc, logg)
+
+	api := router.Group("/reviewerRules")
+	{
+		api.POST("/save", h.SaveRule)
+		api.POST("/delete", h.DeleteRule)
+		api.GET("/get/:user_id", h.GetRules)
+	}
+}
+
+func NewAbsenceHandler(router *gin.Engine, svc services.AbsenceSer, logg *zap.Logger) {
+	h := NewAbsenceHandlerStruct(svc, logg)
+
+	api := router.Group("/absences")
+	{
+		api.POST("/add", h.AddAbsence)
+		api.POST("/delete", h.DeleteAbsence)
+		api.GET("/get/:user_id", h.GetAbsences)
+	}
+}
+
 func NewPullRequestHandler(router *gin.Engine, svc services.PRSer, logg *zap.Logger) {
 	h := NewPullRequestHandlerStruct(svc, logg)
 
@@ -34,5 +60,21 @@ func NewPullRequestHandler(router *gin.Engine, svc services.PRSer, logg *zap.Log
 		api.POST("/create", h.CreatePR)
 		api.POST("/merge", h.MergePR)
 		api.POST("/reassign", h.ReassignPR)
+		api.POST("/addReviewer", h.AddReviewer)
+		api.POST("/removeReviewer", h.RemoveReviewer)
+		api.POST("/decline", h.DeclineReview)
+		api.GET("/declines/user/:user_id", h.GetUserDeclines)
+		api.GET("/declines/team/:team_name", h.GetTeamDeclines)
+		api.POST("/previewReviewers", h.PreviewReviewers)
+		api.POST("/submitReview", h.SubmitReview)
+		api.PATCH("/update", h.UpdatePR)
+		api.POST("/ready", h.ReadyPR)
+		api.POST("/close", h.ClosePR)
+		api.POST("/reopen", h.ReopenPR)
+		api.GET("/reviews/:pull_request_id", h.GetReviewHistory)
+		api.GET("/dependencies/:pull_request_id", h.GetDependencyGraph)
+		api.POST("/revision", h.PushRevision)
+		api.GET("/revisions/:pull_request_id", h.GetRevisions)
+		api.GET("/timeline/:pull_request_id", h.GetTimeline)
 	}
 }
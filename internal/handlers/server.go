@@ -19,7 +19,7 @@ type Server struct {
 	logg *zap.Logger
 }
 
-func NewServer(cfg *config.Config, teamSvc services.TeamSer, userSvc services.UserSer, prSvc services.PRSer, logg *zap.Logger) *Server {
+func NewServer(cfg *config.Config, teamSvc services.TeamSer, userSvc services.UserSer, prSvc services.PRSer, ruleSvc services.ReviewerRuleSer, absenceSvc services.AbsenceSer, logg *zap.Logger) *Server {
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(gin.Logger())
@@ -27,6 +27,8 @@ func NewServer(cfg *config.Config, teamSvc services.TeamSer, userSvc services.Us
 	NewTeamHandler(router, teamSvc, logg)
 	NewUserHandler(router, userSvc, logg)
 	NewPullRequestHandler(router, prSvc, logg)
+	NewReviewerRuleHandler(router, ruleSvc, logg)
+	NewAbsenceHandler(router, absenceSvc, logg)
 
 	srv := &http.Server{
 		Addr:    ":" + cfg.ServerPort,
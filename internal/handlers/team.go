@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +13,8 @@ import (
 	"go.uber.org/zap"
 )
 
+const maxCodeOwnersSize = 1 << 20
+
 type TeamHandler struct {
 	svc  services.TeamSer
 	logg *zap.Logger
@@ -31,14 +34,24 @@ func (h *TeamHandler) CreateTeam(c *gin.Context) {
 
 	teamDomain := mapper.DTOToTeam(teamDTO)
 
-	createdTeam, err := h.svc.Create(c.Request.Context(), teamDomain)
+	createdTeam, topUps, err := h.svc.Create(c.Request.Context(), teamDomain)
+	if errors.Is(err, validateError.TopUpFailed) {
+		// Команда уже сохранена; PR доберёт фоновый добор, если он включён
+		h.logg.Warn("team created without reviewer top-up", zap.Error(err))
+		err = nil
+	}
+	if err != nil && (errors.Is(err, validateError.InvalidReviewersCount) || errors.Is(err, validateError.InvalidFallbackTeam)) {
+		h.logg.Warn("invalid team settings", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		h.logg.Error("failed to create team", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"team": mapper.CreateTeamToDTO(createdTeam)})
+	c.JSON(http.StatusCreated, gin.H{"team": mapper.CreateTeamToDTO(createdTeam), "topped_up": mapper.TopUpsToDTO(topUps)})
 }
 
 func (h *TeamHandler) GetTeamByName(c *gin.Context) {
@@ -53,3 +66,65 @@ func (h *TeamHandler) GetTeamByName(c *gin.Context) {
 
 	c.JSON(http.StatusOK, mapper.TeamToDTO(teamDomain))
 }
+
+func (h *TeamHandler) UploadCodeOwners(c *gin.Context) {
+	teamName := c.Param("team_name")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil || fileHeader.Size > maxCodeOwnersSize {
+		h.logg.Warn("invalid code owners file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logg.Error("failed to open code owners file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxCodeOwnersSize))
+	if err != nil {
+		h.logg.Error("failed to read code owners file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	rules, err := h.svc.UploadCodeOwners(c.Request.Context(), teamName, string(content))
+	if err != nil {
+		h.handleCodeOwnersError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.CodeOwnersToDTO(teamName, rules))
+}
+
+func (h *TeamHandler) GetCodeOwners(c *gin.Context) {
+	teamName := c.Param("team_name")
+
+	rules, err := h.svc.GetCodeOwners(c.Request.Context(), teamName)
+	if err != nil {
+		h.handleCodeOwnersError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.CodeOwnersToDTO(teamName, rules))
+}
+
+func (h *TeamHandler) handleCodeOwnersError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, validateError.TeamNotFound):
+		h.logg.Error("not found team", zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.InvalidCodeOwners):
+		h.logg.Warn("invalid code owners file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	default:
+		h.logg.Error("Internal server error", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/linspacestrom/InterShipAv/internal/dto"
 	"github.com/linspacestrom/InterShipAv/internal/mapper"
 	"github.com/linspacestrom/InterShipAv/internal/services"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
 	"go.uber.org/zap"
 )
 
@@ -29,14 +31,52 @@ func (h *UserHandler) SetActive(c *gin.Context) {
 
 	userDomain := mapper.DTOToUserShort(userReq)
 
-	updatedUser, err := h.svc.SetActive(c.Request.Context(), userDomain.Id, userDomain.IsActive)
+	updatedUser, topUps, err := h.svc.SetActive(c.Request.Context(), userDomain.Id, userDomain.IsActive)
+	if errors.Is(err, validateError.TopUpFailed) {
+		// Активация уже сохранена; PR доберёт фоновый добор, если он включён
+		h.logg.Warn("user activated without reviewer top-up", zap.Error(err))
+		err = nil
+	}
 	if err != nil {
-		h.logg.Error("failed to set active user", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"user": mapper.UserToDTO(updatedUser), "topped_up": mapper.TopUpsToDTO(topUps)})
+}
+
+func (h *UserHandler) SetMaxOpenReviews(c *gin.Context) {
+	var capacityReq dto.UserCapacityRequest
+	if err := c.ShouldBindJSON(&capacityReq); err != nil {
+		h.logg.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	updatedUser, err := h.svc.SetMaxOpenReviews(c.Request.Context(), mapper.DTOToUserCapacity(capacityReq))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": mapper.UserToDTO(updatedUser)})
+}
+
+func (h *UserHandler) SetSchedule(c *gin.Context) {
+	var scheduleReq dto.UserScheduleRequest
+	if err := c.ShouldBindJSON(&scheduleReq); err != nil {
+		h.logg.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"user": mapper.UserToDTO(updatedUser)})
+	updatedUser, err := h.svc.SetSchedule(c.Request.Context(), scheduleReq.Id, mapper.DTOToUserSchedule(scheduleReq))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": mapper.UserToDTO(updatedUser)})
 }
 
 func (h *UserHandler) GetReview(c *gin.Context) {
@@ -51,3 +91,19 @@ func (h *UserHandler) GetReview(c *gin.Context) {
 
 	c.JSON(http.StatusOK, mapper.DomainReviewToDTOReview(userReview))
 }
+
+func (h *UserHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, validateError.UserNotFound):
+		h.logg.Error("User not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+
+	case errors.Is(err, validateError.InvalidSchedule):
+		h.logg.Warn("Invalid schedule", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+	default:
+		h.logg.Error("Internal server error", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
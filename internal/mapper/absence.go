@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/dto"
+)
+
+func DTOToAbsence(req dto.AbsenceRequest) domain.Absence {
+	return domain.Absence{
+		UserId:   req.UserId,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	}
+}
+
+func AbsenceToDTO(absence domain.Absence) dto.AbsenceDTO {
+	return dto.AbsenceDTO{
+		Id:       absence.Id,
+		UserId:   absence.UserId,
+		StartsAt: absence.StartsAt,
+		EndsAt:   absence.EndsAt,
+		Reason:   absence.Reason,
+	}
+}
+
+func AbsencesToDTO(absences []domain.Absence) []dto.AbsenceDTO {
+	res := make([]dto.AbsenceDTO, 0, len(absences))
+	for _, a := range absences {
+		res = append(res, AbsenceToDTO(a))
+	}
+	return res
+}
+
+func UserAbsencesToDTO(userId string, absences []domain.Absence) dto.UserAbsencesResponse {
+	return dto.UserAbsencesResponse{
+		Id:       userId,
+		Absences: AbsencesToDTO(absences),
+	}
+}
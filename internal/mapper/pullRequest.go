@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"strings"
 	"time"
 
 	"github.com/linspacestrom/InterShipAv/internal/domain"
@@ -9,24 +10,86 @@ import (
 
 func DTOToPrCreate(req dto.PRCreateRequest) domain.PullRequestCreate {
 	return domain.PullRequestCreate{
-		Id:       req.Id,
-		Name:     req.Name,
-		AuthorId: req.AuthorId,
+		Id:           req.Id,
+		Name:         req.Name,
+		AuthorId:     req.AuthorId,
+		ChangedFiles: req.ChangedFiles,
+		ReviewerIds:  req.ReviewerIds,
+		Draft:        req.Draft,
+		Description:  req.Description,
+		ExternalURL:  req.ExternalURL,
+		Labels:       req.Labels,
+		Priority:     domain.PullRequestPriority(req.Priority),
+		ParentIds:    req.ParentIds,
 	}
 }
 
+func DTOToPRUpdate(req dto.PRUpdateRequest) domain.PRUpdate {
+	var priority *domain.PullRequestPriority
+	if req.Priority != nil {
+		p := domain.PullRequestPriority(*req.Priority)
+		priority = &p
+	}
+
+	return domain.PRUpdate{
+		Id:          req.Id,
+		Name:        req.Name,
+		Description: req.Description,
+		ExternalURL: req.ExternalURL,
+		AuthorId:    req.AuthorId,
+		Labels:      req.Labels,
+		Priority:    priority,
+	}
+}
+
+func DTOToStatusChange(req dto.PRStatusChangeRequest) domain.PRStatusChange {
+	return domain.PRStatusChange{Id: req.Id, ChangedFiles: req.ChangedFiles}
+}
+
 func PRReadToDTO(res domain.PullRequestRead) dto.PRCreateResponse {
 	return dto.PRCreateResponse{
 		Id:                res.Id,
 		Name:              res.Name,
 		AuthorId:          res.AuthorId,
 		Status:            string(res.Status),
+		Description:       res.Description,
+		ExternalURL:       res.ExternalURL,
+		Labels:            labelsToDTO(res.Labels),
+		Priority:          string(res.Priority),
 		AssignReviewerIds: res.AssignReviewerIds,
+		Reviewers:         ReviewersToDTO(res.Reviewers),
 	}
 }
 
 func DTOtoPRMerge(req dto.PRMergeRequest) domain.PRMerge {
-	return domain.PRMerge{Id: req.Id}
+	return domain.PRMerge{Id: req.Id, Force: req.Force, UserId: req.UserId}
+}
+
+func MergeConditionsToDTO(conditions []domain.MergeCondition) []dto.MergeConditionDTO {
+	res := make([]dto.MergeConditionDTO, 0, len(conditions))
+	for _, c := range conditions {
+		res = append(res, dto.MergeConditionDTO{
+			Condition:      string(c.Kind),
+			Required:       c.Required,
+			Actual:         c.Actual,
+			ReviewerIds:    c.ReviewerIds,
+			PullRequestIds: c.PullRequestIds,
+		})
+	}
+	return res
+}
+
+func DependencyGraphToDTO(graph domain.PRDependencyGraph) dto.PRDependencyGraphResponse {
+	nodes := make([]dto.PRGraphNodeDTO, 0, len(graph.Nodes))
+	for _, n := range graph.Nodes {
+		nodes = append(nodes, dto.PRGraphNodeDTO{Id: n.Id, Name: n.Name, Status: string(n.Status), ParentIds: graph.ParentIds(n.Id)})
+	}
+
+	return dto.PRDependencyGraphResponse{
+		Id:            graph.Id,
+		Nodes:         nodes,
+		BlockingChain: graph.BlockingChain(graph.Id),
+	}
 }
 
 func PRMergeToDTO(res domain.PRMergeRead) dto.PRMergeResponse {
@@ -41,19 +104,56 @@ func PRMergeToDTO(res domain.PRMergeRead) dto.PRMergeResponse {
 		Name:              res.Name,
 		AuthorId:          res.AuthorId,
 		Status:            string(res.Status),
+		Labels:            labelsToDTO(res.Labels),
+		Priority:          string(res.Priority),
 		AssignReviewerIds: res.AssignReviewerIds,
+		Reviewers:         ReviewersToDTO(res.Reviewers),
 		MergedAt:          mergedAt,
+		ForcedBy:          res.ForcedBy,
+		Unmet:             MergeConditionsToDTO(res.Unmet),
 	}
 }
 
 func PrReassignDTOtoDomain(res dto.PRReassignRequest) domain.PRReassign {
-	return domain.PRReassign{Id: res.Id, OldUserId: res.OldUserId}
+	return domain.PRReassign{Id: res.Id, OldUserId: res.OldUserId, NewUserId: res.NewUserId}
+}
+
+func DTOToReviewerChange(req dto.PRReviewerChangeRequest) domain.PRReviewerChange {
+	return domain.PRReviewerChange{Id: req.Id, UserId: req.UserId}
+}
+
+func TopUpsToDTO(topUps []domain.TopUp) []dto.TopUpDTO {
+	res := make([]dto.TopUpDTO, 0, len(topUps))
+	for _, t := range topUps {
+		res = append(res, dto.TopUpDTO{Id: t.PullRequestId, Added: ReviewersToDTO(t.Added)})
+	}
+	return res
+}
+
+func DTOToDecline(req dto.PRDeclineRequest) domain.PRDecline {
+	return domain.PRDecline{Id: req.Id, UserId: req.UserId, Reason: domain.DeclineReason(req.Reason), Comment: req.Comment}
+}
+
+func DeclinesToDTO(declines []domain.ReviewDecline) dto.DeclinesResponse {
+	res := make([]dto.ReviewDeclineDTO, 0, len(declines))
+	for _, d := range declines {
+		res = append(res, dto.ReviewDeclineDTO{
+			PullRequestId: d.PullRequestId,
+			ReviewerId:    d.ReviewerId,
+			TeamName:      d.TeamName,
+			Reason:        string(d.Reason),
+			Comment:       d.Comment,
+			DeclinedAt:    d.DeclinedAt,
+		})
+	}
+	return dto.DeclinesResponse{Declines: res}
 }
 
 func DomainToPRDTO(res domain.PrReassignRead) dto.PrReassignResponse {
 	return dto.PrReassignResponse{
 		PrRead:     DomainPullToDTO(res.PullRequest),
 		ReplacedId: res.ReplacedId,
+		Requested:  res.Requested,
 	}
 }
 
@@ -63,6 +163,126 @@ func DomainPullToDTO(res domain.PullRequestRead) dto.ReassignResponse {
 		Name:              res.Name,
 		AuthorId:          res.AuthorId,
 		Status:            string(res.Status),
+		Description:       res.Description,
+		ExternalURL:       res.ExternalURL,
+		Labels:            labelsToDTO(res.Labels),
+		Priority:          string(res.Priority),
 		AssignReviewerIds: res.AssignReviewerIds,
+		Reviewers:         ReviewersToDTO(res.Reviewers),
+	}
+}
+
+// labelsToDTO отдаёт пустой список вместо null.
+func labelsToDTO(labels []string) []string {
+	if labels == nil {
+		return []string{}
+	}
+	return labels
+}
+
+func ReviewersToDTO(reviewers []domain.AssignedReviewer) []dto.ReviewerDTO {
+	res := make([]dto.ReviewerDTO, 0, len(reviewers))
+	for _, r := range reviewers {
+		res = append(res, dto.ReviewerDTO{Id: r.Id, External: r.External, MatchedRule: r.MatchedRule, Decision: DecisionToDTO(r.Decision)})
+	}
+	return res
+}
+
+func DTOToReviewDecision(req dto.ReviewSubmitRequest) domain.ReviewDecision {
+	return domain.ReviewDecision{
+		PullRequestId: req.Id,
+		ReviewerId:    req.UserId,
+		Decision:      domain.ReviewDecisionKind(req.Decision),
+		Body:          req.Body,
+	}
+}
+
+func DecisionToDTO(d *domain.ReviewDecision) *dto.ReviewDecisionDTO {
+	if d == nil {
+		return nil
+	}
+	return &dto.ReviewDecisionDTO{
+		PullRequestId: d.PullRequestId,
+		ReviewerId:    d.ReviewerId,
+		Decision:      string(d.Decision),
+		Body:          d.Body,
+		SubmittedAt:   d.SubmittedAt,
+		RevisionId:    d.RevisionId,
+		CommitSHA:     d.CommitSHA,
+		Stale:         d.Stale,
+	}
+}
+
+func DTOToRevision(req dto.RevisionPushRequest) domain.Revision {
+	rev := domain.Revision{PullRequestId: req.Id, CommitSHA: strings.ToLower(req.CommitSHA)}
+	if req.PushedAt != nil {
+		rev.PushedAt = *req.PushedAt
+	}
+	return rev
+}
+
+func RevisionToDTO(rev domain.Revision) dto.RevisionDTO {
+	return dto.RevisionDTO{Id: rev.Id, PullRequestId: rev.PullRequestId, CommitSHA: rev.CommitSHA, PushedAt: rev.PushedAt}
+}
+
+func RevisionsToDTO(prId string, revisions []domain.Revision) dto.RevisionsResponse {
+	res := make([]dto.RevisionDTO, 0, len(revisions))
+	for _, rev := range revisions {
+		res = append(res, RevisionToDTO(rev))
+	}
+	return dto.RevisionsResponse{Id: prId, Revisions: res}
+}
+
+func TimelineToDTO(prId string, events []domain.PREvent) dto.TimelineResponse {
+	res := make([]dto.PREventDTO, 0, len(events))
+	for _, e := range events {
+		res = append(res, dto.PREventDTO{
+			Id:         e.Id,
+			Kind:       string(e.Kind),
+			ActorId:    e.ActorId,
+			FromUserId: e.FromUserId,
+			ToUserId:   e.ToUserId,
+			Details:    e.Details,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+	return dto.TimelineResponse{Id: prId, Events: res}
+}
+
+func DecisionsToDTO(decisions []domain.ReviewDecision) []dto.ReviewDecisionDTO {
+	res := make([]dto.ReviewDecisionDTO, 0, len(decisions))
+	for i := range decisions {
+		res = append(res, *DecisionToDTO(&decisions[i]))
+	}
+	return res
+}
+
+func ReviewHistoryToDTO(prId string, decisions []domain.ReviewDecision) dto.ReviewHistoryResponse {
+	return dto.ReviewHistoryResponse{Id: prId, Reviews: DecisionsToDTO(decisions)}
+}
+
+func PreviewToDTO(res domain.ReviewerPreview) dto.PRPreviewResponse {
+	candidates := make([]dto.CandidateDTO, 0, len(res.Candidates))
+	for _, c := range res.Candidates {
+		candidates = append(candidates, dto.CandidateDTO{
+			Id:             c.Id,
+			TeamName:       c.TeamName,
+			OpenReviews:    c.OpenReviews,
+			MaxOpenReviews: c.MaxOpenReviews,
+			LocalTime:      c.Schedule.LocalTime(res.Now).Format(time.RFC3339),
+			InWorkingHours: c.Schedule.InWorkingHours(res.Now),
+		})
+	}
+
+	excluded := make([]dto.ExcludedMemberDTO, 0, len(res.Excluded))
+	for _, e := range res.Excluded {
+		excluded = append(excluded, dto.ExcludedMemberDTO{Id: e.UserId, TeamName: e.TeamName, Reason: string(e.Reason)})
+	}
+
+	return dto.PRPreviewResponse{
+		Candidates:      candidates,
+		Reviewers:       ReviewersToDTO(res.Reviewers),
+		Excluded:        excluded,
+		MinReviewersMet: res.MinReviewersMet,
 	}
 }
@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/dto"
+)
+
+func DTOToReviewerRule(req dto.ReviewerRuleRequest) domain.ReviewerRule {
+	return domain.ReviewerRule{
+		ReviewerId: req.ReviewerId,
+		AuthorId:   req.AuthorId,
+		Kind:       domain.ReviewerRuleKind(req.Kind),
+	}
+}
+
+func DTODeleteToReviewerRule(req dto.ReviewerRuleDeleteRequest) domain.ReviewerRule {
+	return domain.ReviewerRule{
+		ReviewerId: req.ReviewerId,
+		AuthorId:   req.AuthorId,
+	}
+}
+
+func ReviewerRuleToDTO(rule domain.ReviewerRule) dto.ReviewerRuleResponse {
+	return dto.ReviewerRuleResponse{
+		ReviewerId: rule.ReviewerId,
+		AuthorId:   rule.AuthorId,
+		Kind:       string(rule.Kind),
+	}
+}
+
+func UserReviewerRulesToDTO(userId string, rules []domain.ReviewerRule) dto.UserReviewerRulesResponse {
+	res := make([]dto.ReviewerRuleResponse, 0, len(rules))
+	for _, r := range rules {
+		res = append(res, ReviewerRuleToDTO(r))
+	}
+	return dto.UserReviewerRulesResponse{
+		Id:    userId,
+		Rules: res,
+	}
+}
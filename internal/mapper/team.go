@@ -11,8 +11,15 @@ func TeamToDTO(team domain.Team) dto.GetTeamResponse {
 		members[i] = TeamMemberToDTO(m)
 	}
 	return dto.GetTeamResponse{
-		Name:    team.Name,
-		Members: members,
+		Name:              team.Name,
+		ReviewersCount:    team.ReviewersCount,
+		MinReviewers:      team.MinReviewers,
+		CapacityPolicy:    string(team.CapacityPolicy),
+		FallbackTeams:     team.FallbackTeams,
+		SeniorLevel:       team.SeniorLevel,
+		RequiredApprovals: team.RequiredApprovals,
+		StaleReviewPolicy: string(team.StaleReviewPolicy),
+		Members:           members,
 	}
 }
 
@@ -22,8 +29,15 @@ func CreateTeamToDTO(team domain.Team) dto.CreateTeamResponse {
 		members[i] = TeamMemberToDTO(m)
 	}
 	return dto.CreateTeamResponse{
-		Name:    team.Name,
-		Members: members,
+		Name:              team.Name,
+		ReviewersCount:    team.ReviewersCount,
+		MinReviewers:      team.MinReviewers,
+		CapacityPolicy:    string(team.CapacityPolicy),
+		FallbackTeams:     team.FallbackTeams,
+		SeniorLevel:       team.SeniorLevel,
+		RequiredApprovals: team.RequiredApprovals,
+		StaleReviewPolicy: string(team.StaleReviewPolicy),
+		Members:           members,
 	}
 }
 
@@ -32,6 +46,9 @@ func TeamMemberToDTO(member domain.TeamMember) dto.TeamMemberDTO {
 		ID:       member.ID,
 		Username: member.Username,
 		IsActive: &member.IsActive,
+		Level:    member.Level,
+		IsAdmin:  member.IsAdmin,
+		Absences: AbsencesToDTO(member.Absences),
 	}
 }
 
@@ -40,9 +57,31 @@ func DTOToTeam(req dto.CreateTeamRequest) domain.Team {
 	for i, m := range req.Members {
 		members[i] = DTOToTeamMember(m)
 	}
+	reviewersCount := domain.DefaultReviewersCount
+	if req.ReviewersCount != nil {
+		reviewersCount = *req.ReviewersCount
+	}
+
+	minReviewers := 0
+	if req.MinReviewers != nil {
+		minReviewers = *req.MinReviewers
+	}
+
+	requiredApprovals := 0
+	if req.RequiredApprovals != nil {
+		requiredApprovals = *req.RequiredApprovals
+	}
+
 	return domain.Team{
-		Name:    req.Name,
-		Members: members,
+		Name:              req.Name,
+		ReviewersCount:    reviewersCount,
+		MinReviewers:      minReviewers,
+		CapacityPolicy:    domain.CapacityPolicy(req.CapacityPolicy),
+		FallbackTeams:     req.FallbackTeams,
+		SeniorLevel:       req.SeniorLevel,
+		RequiredApprovals: requiredApprovals,
+		StaleReviewPolicy: domain.StaleReviewPolicy(req.StaleReviewPolicy),
+		Members:           members,
 	}
 }
 
@@ -51,5 +90,18 @@ func DTOToTeamMember(m dto.TeamMemberDTO) domain.TeamMember {
 		ID:       m.ID,
 		Username: m.Username,
 		IsActive: *m.IsActive,
+		Level:    m.Level,
+		IsAdmin:  m.IsAdmin,
+	}
+}
+
+func CodeOwnersToDTO(teamName string, rules []domain.CodeOwnerRule) dto.CodeOwnersResponse {
+	res := make([]dto.CodeOwnerRuleDTO, 0, len(rules))
+	for _, r := range rules {
+		res = append(res, dto.CodeOwnerRuleDTO{Pattern: r.Pattern, Owners: r.Owners})
+	}
+	return dto.CodeOwnersResponse{
+		Name:  teamName,
+		Rules: res,
 	}
 }
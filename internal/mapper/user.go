@@ -1,16 +1,61 @@
 package mapper
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/linspacestrom/InterShipAv/internal/domain"
 	"github.com/linspacestrom/InterShipAv/internal/dto"
 )
 
 func UserToDTO(user domain.User) dto.UserResponse {
 	return dto.UserResponse{
-		Id:       user.Id,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		Id:             user.Id,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: user.MaxOpenReviews,
+		Level:          user.Level,
+		IsAdmin:        user.IsAdmin,
+		TimeZone:       user.Schedule.TimeZone,
+		WorkStart:      formatClock(user.Schedule.WorkStart),
+		WorkEnd:        formatClock(user.Schedule.WorkEnd),
+	}
+}
+
+func DTOToUserSchedule(req dto.UserScheduleRequest) domain.WorkSchedule {
+	return domain.WorkSchedule{
+		TimeZone:  req.TimeZone,
+		WorkStart: parseClock(req.WorkStart),
+		WorkEnd:   parseClock(req.WorkEnd),
+	}
+}
+
+// parseClock переводит "ЧЧ:ММ" в минуты от полуночи; формат уже проверен при биндинге.
+func parseClock(value *string) *int {
+	if value == nil {
+		return nil
+	}
+	t, err := time.Parse("15:04", *value)
+	if err != nil {
+		return nil
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return &minutes
+}
+
+func formatClock(minutes *int) *string {
+	if minutes == nil {
+		return nil
+	}
+	value := fmt.Sprintf("%02d:%02d", *minutes/60, *minutes%60)
+	return &value
+}
+
+func DTOToUserCapacity(req dto.UserCapacityRequest) domain.UserCapacity {
+	return domain.UserCapacity{
+		Id:             req.Id,
+		MaxOpenReviews: req.MaxOpenReviews,
 	}
 }
 
@@ -25,7 +70,15 @@ func DomainReviewToDTOReview(user domain.UserReview) dto.UserReviewResponse {
 	pullResponse := make([]dto.PRReadResponse, 0, len(user.PullRequests))
 
 	for _, pr := range user.PullRequests {
-		pullResponse = append(pullResponse, dto.PRReadResponse{Id: pr.Id, Name: pr.Name, AuthorId: pr.AuthorId, Status: string(pr.Status)})
+		pullResponse = append(pullResponse, dto.PRReadResponse{
+			Id:       pr.Id,
+			Name:     pr.Name,
+			AuthorId: pr.AuthorId,
+			Status:   string(pr.Status),
+			Labels:   labelsToDTO(pr.Labels),
+			Priority: string(pr.Priority),
+			Decision: DecisionToDTO(pr.Decision),
+		})
 	}
 
 	return dto.UserReviewResponse{
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+type AbsenceRepo interface {
+	Create(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	Delete(ctx context.Context, id int64) (domain.Absence, error)
+	GetByUserId(ctx context.Context, userId string) ([]domain.Absence, error)
+	GetActualByTeamName(ctx context.Context, teamName string, now time.Time) ([]domain.Absence, error)
+}
+
+const absenceColumns = `absence_id, user_id, starts_at, ends_at, reason`
+
+type AbsenceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAbsenceRepository(pool *pgxpool.Pool) *AbsenceRepository {
+	return &AbsenceRepository{pool: pool}
+}
+
+func (r *AbsenceRepository) Create(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	var created domain.Absence
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO user_absence (user_id, starts_at, ends_at, reason) VALUES ($1, $2, $3, $4)
+		RETURNING `+absenceColumns, absence.UserId, absence.StartsAt, absence.EndsAt, absence.Reason)
+
+	if err := row.Scan(&created.Id, &created.UserId, &created.StartsAt, &created.EndsAt, &created.Reason); err != nil {
+		return created, err
+	}
+
+	return created, nil
+}
+
+func (r *AbsenceRepository) Delete(ctx context.Context, id int64) (domain.Absence, error) {
+	var deleted domain.Absence
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	row := tx.QueryRow(ctx, `DELETE FROM user_absence WHERE absence_id = $1 RETURNING `+absenceColumns, id)
+
+	if err := row.Scan(&deleted.Id, &deleted.UserId, &deleted.StartsAt, &deleted.EndsAt, &deleted.Reason); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return deleted, validateError.AbsenceNotFound
+		}
+		return deleted, err
+	}
+	return deleted, nil
+}
+
+func (r *AbsenceRepository) GetByUserId(ctx context.Context, userId string) ([]domain.Absence, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+absenceColumns+` FROM user_absence WHERE user_id = $1 ORDER BY starts_at, absence_id`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAbsences(rows)
+}
+
+// GetActualByTeamName возвращает отсутствия участников команды, не закончившиеся к now — текущему времени сервиса.
+func (r *AbsenceRepository) GetActualByTeamName(ctx context.Context, teamName string, now time.Time) ([]domain.Absence, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `
+		SELECT a.absence_id, a.user_id, a.starts_at, a.ends_at, a.reason
+		FROM user_absence a
+		JOIN "user" u ON u.id = a.user_id
+		WHERE u.team_name = $1 AND a.ends_at > $2
+		ORDER BY a.starts_at, a.absence_id
+	`, teamName, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAbsences(rows)
+}
+
+func scanAbsences(rows pgx.Rows) ([]domain.Absence, error) {
+	defer rows.Close()
+
+	absences := make([]domain.Absence, 0)
+	for rows.Next() {
+		var a domain.Absence
+		if err := rows.Scan(&a.Id, &a.UserId, &a.StartsAt, &a.EndsAt, &a.Reason); err != nil {
+			return nil, err
+		}
+		absences = append(absences, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return absences, nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+)
+
+type CodeOwnerRepo interface {
+	ReplaceRules(ctx context.Context, teamName string, rules []domain.CodeOwnerRule) error
+	GetRulesByTeamName(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error)
+}
+
+type CodeOwnerRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCodeOwnerRepository(pool *pgxpool.Pool) *CodeOwnerRepository {
+	return &CodeOwnerRepository{pool: pool}
+}
+
+func (r *CodeOwnerRepository) ReplaceRules(ctx context.Context, teamName string, rules []domain.CodeOwnerRule) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM code_owner_rule WHERE team_name = $1`, teamName); err != nil {
+		return err
+	}
+
+	for i, rule := range rules {
+		_, err := tx.Exec(ctx, `INSERT INTO code_owner_rule (team_name, position, pattern, owners) VALUES ($1, $2, $3, $4)`,
+			teamName, i+1, rule.Pattern, rule.Owners)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *CodeOwnerRepository) GetRulesByTeamName(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT pattern, owners FROM code_owner_rule WHERE team_name = $1 ORDER BY position`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]domain.CodeOwnerRule, 0)
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(&rule.Pattern, &rule.Owners); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
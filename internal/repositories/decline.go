@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+)
+
+type DeclineRepo interface {
+	Create(ctx context.Context, decline domain.PRDecline) error
+	GetReviewerIdsByPR(ctx context.Context, prId string) ([]string, error)
+	GetByUserId(ctx context.Context, userId string) ([]domain.ReviewDecline, error)
+	GetByTeamName(ctx context.Context, teamName string) ([]domain.ReviewDecline, error)
+}
+
+const declineColumns = `d.pull_request_id, d.reviewer_id, u.team_name, d.reason, d.comment, d.declined_at`
+
+type DeclineRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDeclineRepository(pool *pgxpool.Pool) *DeclineRepository {
+	return &DeclineRepository{pool: pool}
+}
+
+func (r *DeclineRepository) Create(ctx context.Context, decline domain.PRDecline) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	_, err := tx.Exec(ctx, `INSERT INTO review_decline (pull_request_id, reviewer_id, reason, comment) VALUES ($1, $2, $3, $4)`,
+		decline.Id, decline.UserId, decline.Reason, decline.Comment)
+	return err
+}
+
+func (r *DeclineRepository) GetReviewerIdsByPR(ctx context.Context, prId string) ([]string, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT reviewer_id FROM review_decline WHERE pull_request_id = $1`, prId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *DeclineRepository) GetByUserId(ctx context.Context, userId string) ([]domain.ReviewDecline, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+declineColumns+` FROM review_decline d JOIN "user" u ON u.id = d.reviewer_id
+		WHERE d.reviewer_id = $1 ORDER BY d.declined_at DESC`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeclines(rows)
+}
+
+// GetByTeamName возвращает отказы участников команды по их текущему членству.
+func (r *DeclineRepository) GetByTeamName(ctx context.Context, teamName string) ([]domain.ReviewDecline, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+declineColumns+` FROM review_decline d JOIN "user" u ON u.id = d.reviewer_id
+		WHERE u.team_name = $1 ORDER BY d.declined_at DESC`, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeclines(rows)
+}
+
+func scanDeclines(rows pgx.Rows) ([]domain.ReviewDecline, error) {
+	defer rows.Close()
+
+	declines := make([]domain.ReviewDecline, 0)
+	for rows.Next() {
+		var d domain.ReviewDecline
+		if err := rows.Scan(&d.PullRequestId, &d.ReviewerId, &d.TeamName, &d.Reason, &d.Comment, &d.DeclinedAt); err != nil {
+			return nil, err
+		}
+		declines = append(declines, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return declines, nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+)
+
+type EventRepo interface {
+	Create(ctx context.Context, event domain.PREvent) error
+	GetByPullRequestId(ctx context.Context, prId string) ([]domain.PREvent, error)
+}
+
+const eventColumns = `event_id, pull_request_id, kind, COALESCE(actor_id, ''), COALESCE(from_user_id, ''),
+	COALESCE(to_user_id, ''), COALESCE(details, ''), created_at`
+
+type EventRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEventRepository(pool *pgxpool.Pool) *EventRepository {
+	return &EventRepository{pool: pool}
+}
+
+func (r *EventRepository) Create(ctx context.Context, event domain.PREvent) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	_, err := tx.Exec(ctx, `INSERT INTO pr_events (pull_request_id, kind, actor_id, from_user_id, to_user_id, details)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''))`,
+		event.PullRequestId, event.Kind, event.ActorId, event.FromUserId, event.ToUserId, event.Details)
+	return err
+}
+
+func (r *EventRepository) GetByPullRequestId(ctx context.Context, prId string) ([]domain.PREvent, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+eventColumns+` FROM pr_events WHERE pull_request_id = $1 ORDER BY event_id`, prId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := make([]domain.PREvent, 0)
+	for rows.Next() {
+		var event domain.PREvent
+		if err := scanEvent(rows, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func scanEvent(row pgx.Row, event *domain.PREvent) error {
+	return row.Scan(&event.Id, &event.PullRequestId, &event.Kind, &event.ActorId, &event.FromUserId,
+		&event.ToUserId, &event.Details, &event.CreatedAt)
+}
@@ -1,8 +1,11 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,14 +17,38 @@ import (
 type PrRepo interface {
 	Create(ctx context.Context, pr domain.PullRequestCreate) (domain.PullRequestRead, error)
 	Merge(ctx context.Context, prId string) (domain.PRMergeRead, error)
+	Update(ctx context.Context, update domain.PRUpdate) (domain.PullRequestRead, error)
+	AddParents(ctx context.Context, prId string, parentIds []string) error
+	GetDependencyGraph(ctx context.Context, prId string) (domain.PRDependencyGraph, error)
+	SetStatus(ctx context.Context, prId string, status domain.PullRequestStatus) (domain.PullRequestRead, error)
+	RemoveReviewers(ctx context.Context, prId string) error
+	RecordForcedMerge(ctx context.Context, prId string, adminId string, unmet []domain.MergeCondition) error
 	GetById(ctx context.Context, id string) (domain.PullRequestRead, error)
-	AssignReviewers(ctx context.Context, prId string, userIds []string) ([]string, error)
+	AssignReviewers(ctx context.Context, prId string, reviewers []domain.AssignedReviewer) ([]domain.AssignedReviewer, error)
 	GetPullRequestIdsByReviewerId(ctx context.Context, userId string) ([]string, error)
 	GetPullRequestsByIds(ctx context.Context, prIds []string) ([]domain.PullRequestReviewRead, error)
-	GetReviewersById(ctx context.Context, id string) ([]string, error)
-	Reassign(ctx context.Context, prId string, newReviewerId string, oldReviewerId string) error
+	GetReviewersById(ctx context.Context, id string) ([]domain.AssignedReviewer, error)
+	Reassign(ctx context.Context, prId string, newReviewer domain.AssignedReviewer, oldReviewerId string) error
+	RemoveReviewer(ctx context.Context, prId string, reviewerId string) error
+	SetReviewerExternal(ctx context.Context, prId string, reviewerId string, external bool) error
+	GetRecentReviewCounts(ctx context.Context, authorId string, since time.Time) (map[string]int, error)
+	GetUnderstaffedByTeamName(ctx context.Context, teamName string) ([]domain.PullRequestRead, error)
+	GetUnderstaffedTeamNames(ctx context.Context) ([]string, error)
+	AddDecision(ctx context.Context, decision domain.ReviewDecision) (domain.ReviewDecision, error)
+	GetDecisionsById(ctx context.Context, prId string) ([]domain.ReviewDecision, error)
+	GetLatestDecisionsByReviewerId(ctx context.Context, reviewerId string) (map[string]domain.ReviewDecision, error)
+	MarkDecisionsStale(ctx context.Context, prId string, revisionId int64, kinds []domain.ReviewDecisionKind) ([]domain.ReviewDecision, error)
 }
 
+const prColumns = `pull_request_id, pull_request_name, author_id, status, description, external_url, priority, labels`
+
+const decisionColumns = `decision_id, pull_request_id, reviewer_id, decision, body, submitted_at, revision_id,
+	(SELECT rv.commit_sha FROM pull_request_revision rv WHERE rv.revision_id = review_decision.revision_id), stale`
+
+// understaffedFilter — открытые PR, у которых ревьюеров меньше, чем требует команда автора.
+const understaffedFilter = `p.status = $1
+	AND (SELECT COUNT(*) FROM pr_reviewers r WHERE r.pull_request_id = p.pull_request_id) < t.reviewers_count`
+
 type PullRequestRepository struct {
 	pool *pgxpool.Pool
 }
@@ -33,12 +60,23 @@ func NewPullRequestRepository(pool *pgxpool.Pool) *PullRequestRepository {
 func (r *PullRequestRepository) Create(ctx context.Context, createPR domain.PullRequestCreate) (domain.PullRequestRead, error) {
 	var pr domain.PullRequestRead
 
+	status := domain.StatusOpen
+	if createPR.Draft {
+		status = domain.StatusDraft
+	}
+
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	row := tx.QueryRow(ctx, `INSERT INTO pull_request (pull_request_id, pull_request_name, author_id, status) 
-			VALUES ($1, $2, $3, $4) RETURNING pull_request_id, pull_request_name, author_id, status`, createPR.Id, createPR.Name, createPR.AuthorId, domain.StatusOpen)
+	priority := createPR.Priority
+	if priority == "" {
+		priority = domain.PriorityNormal
+	}
+
+	row := tx.QueryRow(ctx, `INSERT INTO pull_request (pull_request_id, pull_request_name, author_id, status, description, external_url, priority, labels) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING `+prColumns,
+		createPR.Id, createPR.Name, createPR.AuthorId, status, createPR.Description, createPR.ExternalURL, priority, domain.NormalizeLabels(createPR.Labels))
 
-	if err := row.Scan(&pr.Id, &pr.Name, &pr.AuthorId, &pr.Status); err != nil {
+	if err := scanPR(row, &pr); err != nil {
 		return pr, err
 	}
 
@@ -55,25 +93,65 @@ func (r *PullRequestRepository) Merge(ctx context.Context, prId string) (domain.
 			status = $1,
 			merged_at = COALESCE(merged_at, NOW())
 		WHERE pull_request_id = $2
-		RETURNING pull_request_id, pull_request_name, author_id, status, merged_at;
+		RETURNING pull_request_id, pull_request_name, author_id, status, priority, labels, merged_at;
 	`, domain.StatusMerged, prId)
 
 	var prMerged domain.PRMergeRead
 
-	if err := row.Scan(&prMerged.Id, &prMerged.Name, &prMerged.AuthorId, &prMerged.Status, &prMerged.MergedAt); err != nil {
+	if err := row.Scan(&prMerged.Id, &prMerged.Name, &prMerged.AuthorId, &prMerged.Status, &prMerged.Priority, &prMerged.Labels, &prMerged.MergedAt); err != nil {
 		return prMerged, err
 	}
 
 	return prMerged, nil
 }
 
+func (r *PullRequestRepository) SetStatus(ctx context.Context, prId string, status domain.PullRequestStatus) (domain.PullRequestRead, error) {
+	var pr domain.PullRequestRead
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	row := tx.QueryRow(ctx, `UPDATE pull_request SET status = $1 WHERE pull_request_id = $2
+		RETURNING `+prColumns, status, prId)
+
+	if err := scanPR(row, &pr); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pr, validateError.ErrPrNotExist
+		}
+		return pr, err
+	}
+
+	return pr, nil
+}
+
+// RemoveReviewers снимает всех ревьюеров PR; история решений сохраняется.
+func (r *PullRequestRepository) RemoveReviewers(ctx context.Context, prId string) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	_, err := tx.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id = $1`, prId)
+	return err
+}
+
+// RecordForcedMerge сохраняет для аудита, кто из администраторов слил PR в обход политики и какие условия не были выполнены.
+func (r *PullRequestRepository) RecordForcedMerge(ctx context.Context, prId string, adminId string, unmet []domain.MergeCondition) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	kinds := make([]string, 0, len(unmet))
+	for _, c := range unmet {
+		kinds = append(kinds, string(c.Kind))
+	}
+
+	_, err := tx.Exec(ctx, `INSERT INTO merge_override (pull_request_id, admin_id, unmet) VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id) DO NOTHING`, prId, adminId, kinds)
+	return err
+}
+
 func (r *PullRequestRepository) GetById(ctx context.Context, id string) (domain.PullRequestRead, error) {
 	var pr domain.PullRequestRead
 
 	q := transaction.GetQuerier(ctx, r.pool)
-	row := q.QueryRow(ctx, `SELECT pull_request_id, pull_request_name, author_id, status FROM pull_request WHERE pull_request_id = $1`, id)
+	row := q.QueryRow(ctx, `SELECT `+prColumns+` FROM pull_request WHERE pull_request_id = $1`, id)
 
-	if err := row.Scan(&pr.Id, &pr.Name, &pr.AuthorId, &pr.Status); err != nil {
+	if err := scanPR(row, &pr); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return pr, validateError.ErrPrNotExist
 		}
@@ -82,27 +160,64 @@ func (r *PullRequestRepository) GetById(ctx context.Context, id string) (domain.
 	return pr, nil
 }
 
-func (r *PullRequestRepository) AssignReviewers(ctx context.Context, prId string, userIds []string) ([]string, error) {
-	reviewerIds := make([]string, 0, 2)
+// Update меняет только переданные поля PR.
+func (r *PullRequestRepository) Update(ctx context.Context, update domain.PRUpdate) (domain.PullRequestRead, error) {
+	var pr domain.PullRequestRead
+
+	var labels []string
+	if update.Labels != nil {
+		labels = domain.NormalizeLabels(*update.Labels)
+	}
 
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	for _, userId := range userIds {
-		var reviewerId string
-		row := tx.QueryRow(ctx, `INSERT INTO pr_reviewers (pull_request_id, reviewer_id) VALUES ($1, $2) RETURNING reviewer_id`, prId, userId)
-		if err := row.Scan(&reviewerId); err != nil {
-			return reviewerIds, err
+	row := tx.QueryRow(ctx, `
+		UPDATE pull_request SET
+			pull_request_name = COALESCE($1, pull_request_name),
+			description = COALESCE($2, description),
+			external_url = COALESCE($3, external_url),
+			author_id = COALESCE($4, author_id),
+			priority = COALESCE($5, priority),
+			labels = COALESCE($6, labels)
+		WHERE pull_request_id = $7
+		RETURNING `+prColumns, update.Name, update.Description, update.ExternalURL, update.AuthorId, update.Priority, labels, update.Id)
+
+	if err := scanPR(row, &pr); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pr, validateError.ErrPrNotExist
 		}
-		reviewerIds = append(reviewerIds, reviewerId)
+		return pr, err
 	}
+	return pr, nil
+}
 
-	return reviewerIds, nil
+func scanPR(row pgx.Row, pr *domain.PullRequestRead) error {
+	return row.Scan(&pr.Id, &pr.Name, &pr.AuthorId, &pr.Status, &pr.Description, &pr.ExternalURL, &pr.Priority, &pr.Labels)
 }
 
-func (r *PullRequestRepository) Reassign(ctx context.Context, prId string, newReviewerId string, oldReviewerId string) error {
+func (r *PullRequestRepository) AssignReviewers(ctx context.Context, prId string, reviewers []domain.AssignedReviewer) ([]domain.AssignedReviewer, error) {
+	assigned := make([]domain.AssignedReviewer, 0, len(reviewers))
+
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	_, err := tx.Exec(ctx, `UPDATE pr_reviewers SET reviewer_id = $1 WHERE pull_request_id = $2 and reviewer_id = $3`, newReviewerId, prId, oldReviewerId)
+	for _, reviewer := range reviewers {
+		var a domain.AssignedReviewer
+		row := tx.QueryRow(ctx, `INSERT INTO pr_reviewers (pull_request_id, reviewer_id, is_external, matched_rule) VALUES ($1, $2, $3, NULLIF($4, '')) 
+			RETURNING reviewer_id, is_external, COALESCE(matched_rule, '')`, prId, reviewer.Id, reviewer.External, reviewer.MatchedRule)
+		if err := row.Scan(&a.Id, &a.External, &a.MatchedRule); err != nil {
+			return assigned, err
+		}
+		assigned = append(assigned, a)
+	}
+
+	return assigned, nil
+}
+
+func (r *PullRequestRepository) Reassign(ctx context.Context, prId string, newReviewer domain.AssignedReviewer, oldReviewerId string) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	_, err := tx.Exec(ctx, `UPDATE pr_reviewers SET reviewer_id = $1, is_external = $2, matched_rule = NULLIF($3, '') WHERE pull_request_id = $4 and reviewer_id = $5`,
+		newReviewer.Id, newReviewer.External, newReviewer.MatchedRule, prId, oldReviewerId)
 	if err != nil {
 		return err
 	}
@@ -110,6 +225,20 @@ func (r *PullRequestRepository) Reassign(ctx context.Context, prId string, newRe
 
 }
 
+func (r *PullRequestRepository) RemoveReviewer(ctx context.Context, prId string, reviewerId string) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	_, err := tx.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id = $1 and reviewer_id = $2`, prId, reviewerId)
+	return err
+}
+
+func (r *PullRequestRepository) SetReviewerExternal(ctx context.Context, prId string, reviewerId string, external bool) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	_, err := tx.Exec(ctx, `UPDATE pr_reviewers SET is_external = $1 WHERE pull_request_id = $2 and reviewer_id = $3`, external, prId, reviewerId)
+	return err
+}
+
 func (r *PullRequestRepository) GetPullRequestIdsByReviewerId(ctx context.Context, userId string) ([]string, error) {
 	tx := transaction.GetQuerier(ctx, r.pool)
 
@@ -136,10 +265,13 @@ func (r *PullRequestRepository) GetPullRequestIdsByReviewerId(ctx context.Contex
 	return prIds, nil
 }
 
+// GetPullRequestsByIds возвращает только открытые PR: у черновиков ревьюеров ещё нет,
+// а закрытые и слитые ревью больше не ждут.
 func (r *PullRequestRepository) GetPullRequestsByIds(ctx context.Context, prIds []string) ([]domain.PullRequestReviewRead, error) {
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	rows, err := tx.Query(ctx, `SELECT pull_request_id, pull_request_name, author_id, status FROM pull_request WHERE pull_request_id = ANY($1) AND status = $2`, prIds, domain.StatusOpen)
+	rows, err := tx.Query(ctx, `SELECT pull_request_id, pull_request_name, author_id, status, priority, labels, COALESCE(created_at, NOW())
+		FROM pull_request WHERE pull_request_id = ANY($1) AND status = $2`, prIds, domain.StatusOpen)
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +281,7 @@ func (r *PullRequestRepository) GetPullRequestsByIds(ctx context.Context, prIds
 
 	for rows.Next() {
 		var pr domain.PullRequestReviewRead
-		if err := rows.Scan(&pr.Id, &pr.Name, &pr.AuthorId, &pr.Status); err != nil {
+		if err := rows.Scan(&pr.Id, &pr.Name, &pr.AuthorId, &pr.Status, &pr.Priority, &pr.Labels, &pr.CreatedAt); err != nil {
 			return nil, err
 		}
 		prReviews = append(prReviews, pr)
@@ -162,29 +294,343 @@ func (r *PullRequestRepository) GetPullRequestsByIds(ctx context.Context, prIds
 	return prReviews, nil
 }
 
-func (r *PullRequestRepository) GetReviewersById(ctx context.Context, id string) ([]string, error) {
-	reviewerIds := make([]string, 0, 2)
+func (r *PullRequestRepository) GetReviewersById(ctx context.Context, id string) ([]domain.AssignedReviewer, error) {
+	reviewers := make([]domain.AssignedReviewer, 0, domain.DefaultReviewersCount)
 
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	rows, err := tx.Query(ctx, `SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id = $1`, id)
+	rows, err := tx.Query(ctx, `SELECT reviewer_id, is_external, COALESCE(matched_rule, '') FROM pr_reviewers WHERE pull_request_id = $1 ORDER BY reviewer_id`, id)
 	if err != nil {
 		return nil, err
 	}
+
+	for rows.Next() {
+		var reviewer domain.AssignedReviewer
+		if err := rows.Scan(&reviewer.Id, &reviewer.External, &reviewer.MatchedRule); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		reviewers = append(reviewers, reviewer)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// К каждому ревьюеру прикладывается его последнее решение
+	rows, err = tx.Query(ctx, `SELECT DISTINCT ON (reviewer_id) `+decisionColumns+` FROM review_decision
+		WHERE pull_request_id = $1 ORDER BY reviewer_id, decision_id DESC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	latest := make(map[string]domain.ReviewDecision)
+	for rows.Next() {
+		var d domain.ReviewDecision
+		if err := scanDecision(rows, &d); err != nil {
+			return nil, err
+		}
+		latest[d.ReviewerId] = d
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range reviewers {
+		if d, ok := latest[reviewers[i].Id]; ok {
+			reviewers[i].Decision = &d
+		}
+	}
+
+	return reviewers, nil
+}
+
+// GetRecentReviewCounts считает, сколько PR автора, созданных не раньше since, ревьюил каждый пользователь.
+func (r *PullRequestRepository) GetRecentReviewCounts(ctx context.Context, authorId string, since time.Time) (map[string]int, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `
+		SELECT r.reviewer_id, COUNT(*)
+		FROM pr_reviewers r
+		JOIN pull_request p ON p.pull_request_id = r.pull_request_id
+		WHERE p.author_id = $1 AND p.created_at >= $2
+		GROUP BY r.reviewer_id
+	`, authorId, since)
+	if err != nil {
+		return nil, err
+	}
+
 	defer rows.Close()
 
+	counts := make(map[string]int)
 	for rows.Next() {
 		var reviewerId string
-		if err := rows.Scan(&reviewerId); err != nil {
+		var count int
+		if err := rows.Scan(&reviewerId, &count); err != nil {
 			return nil, err
 		}
-		reviewerIds = append(reviewerIds, reviewerId)
+		counts[reviewerId] = count
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return reviewerIds, nil
+	return counts, nil
+}
+
+func (r *PullRequestRepository) GetUnderstaffedByTeamName(ctx context.Context, teamName string) ([]domain.PullRequestRead, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
 
+	rows, err := tx.Query(ctx, `
+		SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status
+		FROM pull_request p
+		JOIN "user" u ON u.id = p.author_id
+		JOIN team t ON t.team_name = u.team_name
+		WHERE `+understaffedFilter+` AND t.team_name = $2
+		ORDER BY p.created_at, p.pull_request_id
+	`, domain.StatusOpen, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	prs := make([]domain.PullRequestRead, 0)
+	for rows.Next() {
+		var pr domain.PullRequestRead
+		if err := rows.Scan(&pr.Id, &pr.Name, &pr.AuthorId, &pr.Status); err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+func (r *PullRequestRepository) GetUnderstaffedTeamNames(ctx context.Context) ([]string, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT t.team_name
+		FROM pull_request p
+		JOIN "user" u ON u.id = p.author_id
+		JOIN team t ON t.team_name = u.team_name
+		WHERE `+understaffedFilter+`
+		ORDER BY t.team_name
+	`, domain.StatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *PullRequestRepository) AddDecision(ctx context.Context, decision domain.ReviewDecision) (domain.ReviewDecision, error) {
+	var saved domain.ReviewDecision
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	// Решение относится к последней ревизии PR, если ревизии уже записывались
+	row := tx.QueryRow(ctx, `INSERT INTO review_decision (pull_request_id, reviewer_id, decision, body, revision_id)
+		VALUES ($1, $2, $3, $4, (SELECT MAX(revision_id) FROM pull_request_revision WHERE pull_request_id = $1))
+		RETURNING `+decisionColumns, decision.PullRequestId, decision.ReviewerId, decision.Decision, decision.Body)
+
+	if err := scanDecision(row, &saved); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+// GetDecisionsById возвращает всю историю решений по PR в порядке отправки.
+func (r *PullRequestRepository) GetDecisionsById(ctx context.Context, prId string) ([]domain.ReviewDecision, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+decisionColumns+` FROM review_decision WHERE pull_request_id = $1 ORDER BY decision_id`, prId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	decisions := make([]domain.ReviewDecision, 0)
+	for rows.Next() {
+		var d domain.ReviewDecision
+		if err := scanDecision(rows, &d); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return decisions, nil
+}
+
+// GetLatestDecisionsByReviewerId возвращает последнее решение ревьюера по каждому PR.
+func (r *PullRequestRepository) GetLatestDecisionsByReviewerId(ctx context.Context, reviewerId string) (map[string]domain.ReviewDecision, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT DISTINCT ON (pull_request_id) `+decisionColumns+` FROM review_decision
+		WHERE reviewer_id = $1 ORDER BY pull_request_id, decision_id DESC`, reviewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	decisions := make(map[string]domain.ReviewDecision)
+	for rows.Next() {
+		var d domain.ReviewDecision
+		if err := scanDecision(rows, &d); err != nil {
+			return nil, err
+		}
+		decisions[d.PullRequestId] = d
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return decisions, nil
+}
+
+func (r *PullRequestRepository) AddParents(ctx context.Context, prId string, parentIds []string) error {
+	if len(parentIds) == 0 {
+		return nil
+	}
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	_, err := tx.Exec(ctx, `INSERT INTO pull_request_dependency (pull_request_id, parent_id)
+		SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING`, prId, parentIds)
+	return err
+}
+
+// GetDependencyGraph собирает стек вокруг PR: рёбра ко всем предкам и потомкам и сами эти PR.
+func (r *PullRequestRepository) GetDependencyGraph(ctx context.Context, prId string) (domain.PRDependencyGraph, error) {
+	graph := domain.PRDependencyGraph{Id: prId, Nodes: make([]domain.PRGraphNode, 0), Edges: make([]domain.PRDependency, 0)}
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `
+		WITH RECURSIVE up AS (
+			SELECT pull_request_id, parent_id FROM pull_request_dependency WHERE pull_request_id = $1
+			UNION
+			SELECT d.pull_request_id, d.parent_id FROM pull_request_dependency d JOIN up ON d.pull_request_id = up.parent_id
+		), down AS (
+			SELECT pull_request_id, parent_id FROM pull_request_dependency WHERE parent_id = $1
+			UNION
+			SELECT d.pull_request_id, d.parent_id FROM pull_request_dependency d JOIN down ON d.parent_id = down.pull_request_id
+		)
+		SELECT pull_request_id, parent_id FROM up
+		UNION
+		SELECT pull_request_id, parent_id FROM down
+		ORDER BY 1, 2`, prId)
+	if err != nil {
+		return graph, err
+	}
+
+	ids := []string{prId}
+	for rows.Next() {
+		var e domain.PRDependency
+		if err := rows.Scan(&e.ChildId, &e.ParentId); err != nil {
+			rows.Close()
+			return graph, err
+		}
+		graph.Edges = append(graph.Edges, e)
+		ids = append(ids, e.ChildId, e.ParentId)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return graph, err
+	}
+
+	rows, err = tx.Query(ctx, `SELECT pull_request_id, pull_request_name, status FROM pull_request
+		WHERE pull_request_id = ANY($1) ORDER BY pull_request_id`, ids)
+	if err != nil {
+		return graph, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n domain.PRGraphNode
+		if err := rows.Scan(&n.Id, &n.Name, &n.Status); err != nil {
+			return graph, err
+		}
+		graph.Nodes = append(graph.Nodes, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return graph, err
+	}
+
+	return graph, nil
+}
+
+// MarkDecisionsStale помечает устаревшими действующие решения по PR с видами kinds,
+// принятые не на ревизии revisionId. Возвращает помеченные решения.
+func (r *PullRequestRepository) MarkDecisionsStale(ctx context.Context, prId string, revisionId int64, kinds []domain.ReviewDecisionKind) ([]domain.ReviewDecision, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `UPDATE review_decision SET stale = true
+		WHERE pull_request_id = $1 AND stale = false AND revision_id IS DISTINCT FROM $2 AND decision = ANY($3)
+		RETURNING `+decisionColumns, prId, revisionId, kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	decisions := make([]domain.ReviewDecision, 0)
+	for rows.Next() {
+		var d domain.ReviewDecision
+		if err := scanDecision(rows, &d); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(decisions, func(a, b domain.ReviewDecision) int { return cmp.Compare(a.Id, b.Id) })
+	return decisions, nil
+}
+
+func scanDecision(row pgx.Row, d *domain.ReviewDecision) error {
+	var commitSha *string
+	if err := row.Scan(&d.Id, &d.PullRequestId, &d.ReviewerId, &d.Decision, &d.Body, &d.SubmittedAt, &d.RevisionId, &commitSha, &d.Stale); err != nil {
+		return err
+	}
+	if commitSha != nil {
+		d.CommitSHA = *commitSha
+	}
+	return nil
 }
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+type ReviewerRuleRepo interface {
+	Upsert(ctx context.Context, rule domain.ReviewerRule) (domain.ReviewerRule, error)
+	Delete(ctx context.Context, reviewerId string, authorId string) error
+	GetByUserId(ctx context.Context, userId string) ([]domain.ReviewerRule, error)
+}
+
+type ReviewerRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReviewerRuleRepository(pool *pgxpool.Pool) *ReviewerRuleRepository {
+	return &ReviewerRuleRepository{pool: pool}
+}
+
+func (r *ReviewerRuleRepository) Upsert(ctx context.Context, rule domain.ReviewerRule) (domain.ReviewerRule, error) {
+	var saved domain.ReviewerRule
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO reviewer_rule (reviewer_id, author_id, kind) VALUES ($1, $2, $3)
+		ON CONFLICT (reviewer_id, author_id) DO UPDATE SET kind = EXCLUDED.kind
+		RETURNING reviewer_id, author_id, kind
+	`, rule.ReviewerId, rule.AuthorId, rule.Kind)
+
+	if err := row.Scan(&saved.ReviewerId, &saved.AuthorId, &saved.Kind); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (r *ReviewerRuleRepository) Delete(ctx context.Context, reviewerId string, authorId string) error {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM reviewer_rule WHERE reviewer_id = $1 AND author_id = $2`, reviewerId, authorId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return validateError.ReviewerRuleNotFound
+	}
+	return nil
+}
+
+func (r *ReviewerRuleRepository) GetByUserId(ctx context.Context, userId string) ([]domain.ReviewerRule, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT reviewer_id, author_id, kind FROM reviewer_rule WHERE reviewer_id = $1 OR author_id = $1 ORDER BY reviewer_id, author_id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]domain.ReviewerRule, 0)
+	for rows.Next() {
+		var rule domain.ReviewerRule
+		if err := rows.Scan(&rule.ReviewerId, &rule.AuthorId, &rule.Kind); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+)
+
+type RevisionRepo interface {
+	Create(ctx context.Context, revision domain.Revision) (domain.Revision, error)
+	GetByPullRequestId(ctx context.Context, prId string) ([]domain.Revision, error)
+}
+
+const revisionColumns = `revision_id, pull_request_id, commit_sha, pushed_at`
+
+type RevisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRevisionRepository(pool *pgxpool.Pool) *RevisionRepository {
+	return &RevisionRepository{pool: pool}
+}
+
+func (r *RevisionRepository) Create(ctx context.Context, revision domain.Revision) (domain.Revision, error) {
+	var created domain.Revision
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	row := tx.QueryRow(ctx, `INSERT INTO pull_request_revision (pull_request_id, commit_sha, pushed_at) VALUES ($1, $2, $3)
+		RETURNING `+revisionColumns, revision.PullRequestId, revision.CommitSHA, revision.PushedAt)
+
+	if err := scanRevision(row, &created); err != nil {
+		return created, err
+	}
+
+	return created, nil
+}
+
+func (r *RevisionRepository) GetByPullRequestId(ctx context.Context, prId string) ([]domain.Revision, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+revisionColumns+` FROM pull_request_revision WHERE pull_request_id = $1 ORDER BY revision_id`, prId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	revisions := make([]domain.Revision, 0)
+	for rows.Next() {
+		var rev domain.Revision
+		if err := scanRevision(rows, &rev); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func scanRevision(row pgx.Row, rev *domain.Revision) error {
+	return row.Scan(&rev.Id, &rev.PullRequestId, &rev.CommitSHA, &rev.PushedAt)
+}
@@ -14,6 +14,8 @@ import (
 type TeamRepo interface {
 	Create(ctx context.Context, team domain.Team) (domain.Team, error)
 	GetByName(ctx context.Context, name string) (domain.Team, error)
+	LockRotation(ctx context.Context, name string) ([]domain.RotationEntry, int, error)
+	SetRotationCursor(ctx context.Context, name string, cursor int) error
 }
 
 type TeamRepository struct {
@@ -29,13 +31,22 @@ func (t *TeamRepository) Create(ctx context.Context, team domain.Team) (domain.T
 
 	tx := transaction.GetQuerier(ctx, t.pool)
 
-	query := `INSERT INTO team (team_name) VALUES ($1) RETURNING team_name`
-	row := tx.QueryRow(ctx, query, team.Name)
+	query := `INSERT INTO team (team_name, reviewers_count, min_reviewers, capacity_policy, senior_level, required_approvals, stale_review_policy) VALUES ($1, $2, $3, $4, $5, $6, $7) 
+			RETURNING team_name, reviewers_count, min_reviewers, capacity_policy, senior_level, required_approvals, stale_review_policy`
+	row := tx.QueryRow(ctx, query, team.Name, team.ReviewersCount, team.MinReviewers, team.CapacityPolicy, team.SeniorLevel, team.RequiredApprovals, team.StaleReviewPolicy)
 
-	if err := row.Scan(&createdTeam.Name); err != nil {
+	if err := row.Scan(&createdTeam.Name, &createdTeam.ReviewersCount, &createdTeam.MinReviewers, &createdTeam.CapacityPolicy, &createdTeam.SeniorLevel, &createdTeam.RequiredApprovals, &createdTeam.StaleReviewPolicy); err != nil {
 		return createdTeam, err
 	}
 
+	for i, fallback := range team.FallbackTeams {
+		_, err := tx.Exec(ctx, `INSERT INTO team_fallback (team_name, fallback_team_name, priority) VALUES ($1, $2, $3)`, createdTeam.Name, fallback, i+1)
+		if err != nil {
+			return createdTeam, err
+		}
+	}
+	createdTeam.FallbackTeams = team.FallbackTeams
+
 	return createdTeam, nil
 }
 
@@ -44,10 +55,12 @@ func (t *TeamRepository) GetByName(ctx context.Context, name string) (domain.Tea
 
 	tx := transaction.GetQuerier(ctx, t.pool)
 
-	query := `SELECT * FROM team WHERE team.team_name = $1`
+	query := `SELECT team_name, reviewers_count, min_reviewers, capacity_policy, senior_level, required_approvals, stale_review_policy,
+			COALESCE((SELECT array_agg(f.fallback_team_name ORDER BY f.priority) FROM team_fallback f WHERE f.team_name = team.team_name), '{}')
+		FROM team WHERE team.team_name = $1`
 	row := tx.QueryRow(ctx, query, name)
 
-	if err := row.Scan(&team.Name); err != nil {
+	if err := row.Scan(&team.Name, &team.ReviewersCount, &team.MinReviewers, &team.CapacityPolicy, &team.SeniorLevel, &team.RequiredApprovals, &team.StaleReviewPolicy, &team.FallbackTeams); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return team, validateError.TeamNotFound
 		}
@@ -56,3 +69,45 @@ func (t *TeamRepository) GetByName(ctx context.Context, name string) (domain.Tea
 
 	return team, nil
 }
+
+// LockRotation блокирует строку команды до конца транзакции и возвращает порядок ротации с курсором.
+func (t *TeamRepository) LockRotation(ctx context.Context, name string) ([]domain.RotationEntry, int, error) {
+	tx := transaction.GetQuerier(ctx, t.pool)
+
+	var cursor int
+	row := tx.QueryRow(ctx, `SELECT rotation_cursor FROM team WHERE team_name = $1 FOR UPDATE`, name)
+	if err := row.Scan(&cursor); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, validateError.TeamNotFound
+		}
+		return nil, 0, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT user_id, position FROM team_rotation WHERE team_name = $1 ORDER BY position`, name)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []domain.RotationEntry
+	for rows.Next() {
+		var e domain.RotationEntry
+		if err := rows.Scan(&e.UserId, &e.Position); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, cursor, nil
+}
+
+func (t *TeamRepository) SetRotationCursor(ctx context.Context, name string, cursor int) error {
+	tx := transaction.GetQuerier(ctx, t.pool)
+
+	_, err := tx.Exec(ctx, `UPDATE team SET rotation_cursor = $1 WHERE team_name = $2`, cursor, name)
+	return err
+}
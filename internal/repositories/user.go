@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,10 +19,32 @@ type UserRepo interface {
 	GetUserByTeamName(ctx context.Context, name string) ([]domain.TeamMember, error)
 	GetById(ctx context.Context, id string) (domain.User, error)
 	SetActiveById(ctx context.Context, id string, isActive bool) (domain.User, error)
-	GetNewReviewers(ctx context.Context, name string, excludeUserId string) ([]string, error)
-	GetNewReviewer(ctx context.Context, authorId string, teamName string, reviewersIds []string) (string, error)
+	SetMaxOpenReviewsById(ctx context.Context, id string, maxOpenReviews *int) (domain.User, error)
+	SetScheduleById(ctx context.Context, id string, schedule domain.WorkSchedule) (domain.User, error)
+	GetNewReviewers(ctx context.Context, name string, excludeUserId string, now time.Time) ([]domain.ReviewerCandidate, error)
+	GetNewReviewer(ctx context.Context, authorId string, teamName string, reviewersIds []string, now time.Time) ([]domain.ReviewerCandidate, error)
+	GetCandidatesByIds(ctx context.Context, ids []string, excludeUserId string, now time.Time) ([]domain.ReviewerCandidate, error)
 }
 
+const userColumns = `id, username, team_name, is_active, max_open_reviews, level, is_admin, time_zone, work_start, work_end`
+
+// candidateColumns и candidateFilter ожидают автора PR в параметре $1, статус OPEN в параметре $3
+// и текущее время сервиса в параметре $4 — то же, по которому строится предпросмотр.
+const candidateColumns = `u.id, u.team_name, u.max_open_reviews, u.level, u.time_zone, u.work_start, u.work_end,
+	(SELECT COUNT(*) FROM pr_reviewers r
+		JOIN pull_request p ON p.pull_request_id = r.pull_request_id
+		WHERE r.reviewer_id = u.id AND p.status = $3) AS open_reviews,
+	EXISTS (SELECT 1 FROM reviewer_rule rr
+		WHERE rr.reviewer_id = u.id AND rr.author_id = $1 AND rr.kind = 'prefer') AS preferred`
+
+// candidateFilter — жёсткие ограничения: активность, не автор, нет запрета на ревью автора
+// и нет текущего отсутствия.
+const candidateFilter = `u.is_active = true AND u.id != $1
+	AND NOT EXISTS (SELECT 1 FROM reviewer_rule rr
+		WHERE rr.reviewer_id = u.id AND rr.author_id = $1 AND rr.kind = 'exclude')
+	AND NOT EXISTS (SELECT 1 FROM user_absence a
+		WHERE a.user_id = u.id AND a.starts_at <= $4 AND a.ends_at > $4)`
+
 type UserRepository struct {
 	pool *pgxpool.Pool
 }
@@ -34,21 +57,37 @@ func (r *UserRepository) AddUsersToTeam(ctx context.Context, users []domain.Team
 	tx := transaction.GetQuerier(ctx, r.pool)
 
 	valueStrings := make([]string, 0, len(users))
-	valueArgs := make([]any, 0, len(users)*4)
+	valueArgs := make([]any, 0, len(users)*6)
 
 	arg := 1
 
+	ids := make([]string, 0, len(users))
+	levels := make([]*int, 0, len(users))
+	admins := make([]*bool, 0, len(users))
+
 	for _, u := range users {
+		level := domain.DefaultLevel
+		if u.Level != nil {
+			level = *u.Level
+		}
+		isAdmin := u.IsAdmin != nil && *u.IsAdmin
+
 		valueStrings = append(
 			valueStrings,
-			fmt.Sprintf("($%d, $%d, $%d, $%d)", arg, arg+1, arg+2, arg+3),
+			fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", arg, arg+1, arg+2, arg+3, arg+4, arg+5),
 		)
-		valueArgs = append(valueArgs, u.ID, u.Username, teamName, u.IsActive)
-		arg += 4
+		valueArgs = append(valueArgs, u.ID, u.Username, teamName, u.IsActive, level, isAdmin)
+		arg += 6
+
+		ids = append(ids, u.ID)
+		levels = append(levels, u.Level)
+		admins = append(admins, u.IsAdmin)
 	}
 
+	// level и is_admin не перезаписываются у существующих пользователей: явно переданные значения
+	// применяются отдельным запросом, пропущенные оставляют сохранённые
 	query := fmt.Sprintf(`
-		INSERT INTO "user" (id, username, team_name, is_active)
+		INSERT INTO "user" (id, username, team_name, is_active, level, is_admin)
 		VALUES %s
 		ON CONFLICT (id) DO UPDATE SET
 			username = EXCLUDED.username,
@@ -56,14 +95,48 @@ func (r *UserRepository) AddUsersToTeam(ctx context.Context, users []domain.Team
 			is_active = EXCLUDED.is_active
 	`, strings.Join(valueStrings, ","))
 
-	_, err := tx.Exec(ctx, query, valueArgs...)
+	if _, err := tx.Exec(ctx, query, valueArgs...); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `
+		UPDATE "user" u SET level = COALESCE(v.level, u.level), is_admin = COALESCE(v.is_admin, u.is_admin)
+		FROM unnest($1::text[], $2::int[], $3::boolean[]) AS v(id, level, is_admin)
+		WHERE u.id = v.id
+	`, ids, levels, admins)
+	if err != nil {
+		return err
+	}
+
+	return r.syncRotation(ctx, tx, teamName)
+}
+
+// syncRotation убирает из ротации ушедших из команды пользователей и добавляет новых в конец очереди.
+func (r *UserRepository) syncRotation(ctx context.Context, tx transaction.Querier, teamName string) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM team_rotation tr
+		USING "user" u
+		WHERE tr.user_id = u.id AND tr.team_name != u.team_name
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO team_rotation (team_name, user_id, position)
+		SELECT $1, u.id,
+			COALESCE((SELECT MAX(position) FROM team_rotation WHERE team_name = $1), 0) + ROW_NUMBER() OVER (ORDER BY u.id)
+		FROM "user" u
+		WHERE u.team_name = $1
+			AND NOT EXISTS (SELECT 1 FROM team_rotation tr WHERE tr.user_id = u.id)
+	`, teamName)
 	return err
 }
 
 func (r *UserRepository) GetUserByTeamName(ctx context.Context, name string) ([]domain.TeamMember, error) {
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	rows, err := tx.Query(ctx, `SELECT id, username, is_active FROM "user" WHERE team_name = $1`, name)
+	rows, err := tx.Query(ctx, `SELECT id, username, is_active, level, is_admin FROM "user" WHERE team_name = $1`, name)
 
 	if err != nil {
 		return nil, err
@@ -74,7 +147,7 @@ func (r *UserRepository) GetUserByTeamName(ctx context.Context, name string) ([]
 	var users []domain.TeamMember
 	for rows.Next() {
 		var u domain.TeamMember
-		if err := rows.Scan(&u.ID, &u.Username, &u.IsActive); err != nil {
+		if err := rows.Scan(&u.ID, &u.Username, &u.IsActive, &u.Level, &u.IsAdmin); err != nil {
 			return nil, err
 		}
 		users = append(users, u)
@@ -91,9 +164,9 @@ func (r *UserRepository) GetById(ctx context.Context, id string) (domain.User, e
 	var user domain.User
 
 	q := transaction.GetQuerier(ctx, r.pool)
-	row := q.QueryRow(ctx, `SELECT id, username, team_name, is_active FROM "user" WHERE id = $1`, id)
+	row := q.QueryRow(ctx, `SELECT `+userColumns+` FROM "user" WHERE id = $1`, id)
 
-	if err := row.Scan(&user.Id, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+	if err := scanUser(row, &user); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return user, validateError.UserNotFound
 		}
@@ -107,9 +180,9 @@ func (r *UserRepository) SetActiveById(ctx context.Context, id string, isActive
 
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	row := tx.QueryRow(ctx, `UPDATE "user" SET is_active = $1 WHERE id = $2 RETURNING id, username, team_name, is_active`, isActive, id)
+	row := tx.QueryRow(ctx, `UPDATE "user" SET is_active = $1 WHERE id = $2 RETURNING `+userColumns, isActive, id)
 
-	if err := row.Scan(&user.Id, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+	if err := scanUser(row, &user); err != nil {
 		return user, err
 	}
 
@@ -117,57 +190,105 @@ func (r *UserRepository) SetActiveById(ctx context.Context, id string, isActive
 
 }
 
-func (r *UserRepository) GetNewReviewers(ctx context.Context, name string, excludeUserId string) ([]string, error) {
-	userIds := make([]string, 0, 2)
+func (r *UserRepository) SetMaxOpenReviewsById(ctx context.Context, id string, maxOpenReviews *int) (domain.User, error) {
+	var user domain.User
 
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	rows, err := tx.Query(ctx, `SELECT id FROM "user" WHERE is_active = true and id != $1 and team_name = $2 ORDER BY RANDOM() LIMIT 2`, excludeUserId, name)
+	row := tx.QueryRow(ctx, `UPDATE "user" SET max_open_reviews = $1 WHERE id = $2 RETURNING `+userColumns, maxOpenReviews, id)
 
-	if err != nil {
-		return nil, err
+	if err := scanUser(row, &user); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, validateError.UserNotFound
+		}
+		return user, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return nil, err
+	return user, nil
+}
+
+func (r *UserRepository) SetScheduleById(ctx context.Context, id string, schedule domain.WorkSchedule) (domain.User, error) {
+	var user domain.User
+
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	row := tx.QueryRow(ctx, `UPDATE "user" SET time_zone = $1, work_start = $2, work_end = $3 WHERE id = $4 RETURNING `+userColumns,
+		schedule.TimeZone, schedule.WorkStart, schedule.WorkEnd, id)
+
+	if err := scanUser(row, &user); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, validateError.UserNotFound
 		}
-		userIds = append(userIds, id)
+		return user, err
 	}
-	if err := rows.Err(); err != nil {
+
+	return user, nil
+}
+
+func scanUser(row pgx.Row, user *domain.User) error {
+	return row.Scan(&user.Id, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews, &user.Level, &user.IsAdmin,
+		&user.Schedule.TimeZone, &user.Schedule.WorkStart, &user.Schedule.WorkEnd)
+}
+
+func (r *UserRepository) GetNewReviewers(ctx context.Context, name string, excludeUserId string, now time.Time) ([]domain.ReviewerCandidate, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+candidateColumns+` FROM "user" u WHERE `+candidateFilter+` and u.team_name = $2 ORDER BY u.id`, excludeUserId, name, domain.StatusOpen, now)
+	if err != nil {
 		return nil, err
 	}
 
-	return userIds, nil
+	return scanCandidates(rows)
 }
 
-func (r *UserRepository) GetNewReviewer(ctx context.Context, authorId string, teamName string, reviewersIds []string) (string, error) {
-	var newReviewerId string
-
+func (r *UserRepository) GetNewReviewer(ctx context.Context, authorId string, teamName string, reviewersIds []string, now time.Time) ([]domain.ReviewerCandidate, error) {
 	tx := transaction.GetQuerier(ctx, r.pool)
 
-	row := tx.QueryRow(
+	rows, err := tx.Query(
 		ctx,
-		`SELECT id FROM "user" 
-     	WHERE is_active = true 
-       	AND id != $1 
-       	AND team_name = $2 
-       	AND id != ALL($3) 
-     	ORDER BY RANDOM() 
-     	LIMIT 1`,
+		`SELECT `+candidateColumns+` FROM "user" u 
+     	WHERE `+candidateFilter+` 
+       	AND u.team_name = $2 
+       	AND u.id != ALL($5) 
+     	ORDER BY u.id`,
 		authorId,
 		teamName,
+		domain.StatusOpen,
+		now,
 		reviewersIds,
 	)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := row.Scan(&newReviewerId); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return "", validateError.NoCandidate
+	return scanCandidates(rows)
+}
+
+func (r *UserRepository) GetCandidatesByIds(ctx context.Context, ids []string, excludeUserId string, now time.Time) ([]domain.ReviewerCandidate, error) {
+	tx := transaction.GetQuerier(ctx, r.pool)
+
+	rows, err := tx.Query(ctx, `SELECT `+candidateColumns+` FROM "user" u WHERE `+candidateFilter+` and u.id = ANY($2) ORDER BY u.id`, excludeUserId, ids, domain.StatusOpen, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCandidates(rows)
+}
+
+func scanCandidates(rows pgx.Rows) ([]domain.ReviewerCandidate, error) {
+	defer rows.Close()
+
+	var candidates []domain.ReviewerCandidate
+	for rows.Next() {
+		var c domain.ReviewerCandidate
+		if err := rows.Scan(&c.Id, &c.TeamName, &c.MaxOpenReviews, &c.Level, &c.Schedule.TimeZone, &c.Schedule.WorkStart, &c.Schedule.WorkEnd, &c.OpenReviews, &c.Preferred); err != nil {
+			return nil, err
 		}
-		return "", err
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return newReviewerId, nil
+	return candidates, nil
 }
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/repositories"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+type AbsenceSer interface {
+	Create(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	Delete(ctx context.Context, id int64) ([]domain.TopUp, error)
+	GetByUserId(ctx context.Context, userId string) ([]domain.Absence, error)
+}
+
+type AbsenceService struct {
+	absenceRepo repositories.AbsenceRepo
+	userRepo    repositories.UserRepo
+	topUp       ReviewerTopUp
+	tm          transaction.Runner
+}
+
+func NewAbsenceService(absenceRepo repositories.AbsenceRepo, userRepo repositories.UserRepo, topUp ReviewerTopUp, tm transaction.Runner) AbsenceService {
+	return AbsenceService{absenceRepo: absenceRepo, userRepo: userRepo, topUp: topUp, tm: tm}
+}
+
+func (s *AbsenceService) Create(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	var created domain.Absence
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		if !absence.EndsAt.After(absence.StartsAt) {
+			return validateError.InvalidAbsence
+		}
+
+		if _, err := s.userRepo.GetById(ctx, absence.UserId); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = s.absenceRepo.Create(ctx, absence)
+		return err
+	})
+
+	if err != nil {
+		return domain.Absence{}, err
+	}
+
+	return created, nil
+}
+
+// Delete досрочно возвращает пользователя и добирает ревьюеров в PR его команды.
+// Сбой добора не отменяет удаление отсутствия и возвращается как TopUpFailed.
+func (s *AbsenceService) Delete(ctx context.Context, id int64) ([]domain.TopUp, error) {
+	var user domain.User
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		absence, err := s.absenceRepo.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		user, err = s.userRepo.GetById(ctx, absence.UserId)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	topUps, err := s.topUp.TopUpTeam(ctx, user.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", validateError.TopUpFailed, err)
+	}
+	return topUps, nil
+}
+
+func (s *AbsenceService) GetByUserId(ctx context.Context, userId string) ([]domain.Absence, error) {
+	if _, err := s.userRepo.GetById(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	return s.absenceRepo.GetByUserId(ctx, userId)
+}
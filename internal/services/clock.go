@@ -0,0 +1,18 @@
+package services
+
+import "time"
+
+// Clock отдаёт текущее время; подменяется в тестах, чтобы выбор по рабочим часам был детерминированным.
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func NewSystemClock() SystemClock {
+	return SystemClock{}
+}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
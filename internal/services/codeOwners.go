@@ -0,0 +1,113 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// ParseCodeOwners разбирает файл в формате CODEOWNERS: "шаблон владелец1 владелец2".
+// Пустые строки и комментарии пропускаются.
+func ParseCodeOwners(content string) ([]domain.CodeOwnerRule, error) {
+	rules := make([]domain.CodeOwnerRule, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%w: line %d has no owners", validateError.InvalidCodeOwners, line)
+		}
+
+		if _, err := compilePattern(fields[0]); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", validateError.InvalidCodeOwners, line, err)
+		}
+
+		for _, owner := range fields[1:] {
+			if !strings.HasPrefix(owner, "@") || len(owner) == 1 {
+				return nil, fmt.Errorf("%w: line %d: owner %q must start with @", validateError.InvalidCodeOwners, line, owner)
+			}
+		}
+
+		rules = append(rules, domain.CodeOwnerRule{Pattern: fields[0], Owners: fields[1:]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", validateError.InvalidCodeOwners, err)
+	}
+
+	return rules, nil
+}
+
+// MatchCodeOwners возвращает для каждого файла последнее подходящее правило, как в CODEOWNERS.
+// Правила возвращаются в порядке первого упоминания, без повторов.
+func MatchCodeOwners(rules []domain.CodeOwnerRule, files []string) []domain.CodeOwnerRule {
+	compiled := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		compiled[i], _ = compilePattern(rule.Pattern)
+	}
+
+	matched := make([]domain.CodeOwnerRule, 0)
+	seen := make(map[int]bool)
+	for _, file := range files {
+		file = strings.TrimPrefix(file, "/")
+		for i := len(rules) - 1; i >= 0; i-- {
+			if compiled[i] == nil || !compiled[i].MatchString(file) {
+				continue
+			}
+			if !seen[i] {
+				seen[i] = true
+				matched = append(matched, rules[i])
+			}
+			break
+		}
+	}
+
+	return matched
+}
+
+// compilePattern переводит glob в регулярное выражение:
+// "**" — любое число каталогов, "*" и "?" — в пределах одного сегмента.
+// Шаблон без "/" внутри совпадает с файлом или каталогом на любой глубине,
+// а шаблон каталога — со всем его содержимым.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	p := strings.Trim(pattern, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+
+	return regexp.Compile(b.String())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"slices"
 
 	"github.com/linspacestrom/InterShipAv/internal/domain"
 	"github.com/linspacestrom/InterShipAv/internal/repositories"
@@ -15,17 +16,41 @@ type PRSer interface {
 	Create(ctx context.Context, createPr domain.PullRequestCreate) (domain.PullRequestRead, error)
 	Merge(ctx context.Context, prMerger domain.PRMerge) (domain.PRMergeRead, error)
 	Reassign(ctx context.Context, pr domain.PRReassign) (domain.PrReassignRead, error)
+	PreviewReviewers(ctx context.Context, createPr domain.PullRequestCreate) (domain.ReviewerPreview, error)
+	AddReviewer(ctx context.Context, change domain.PRReviewerChange) (domain.PullRequestRead, error)
+	RemoveReviewer(ctx context.Context, change domain.PRReviewerChange) (domain.PullRequestRead, error)
+	Decline(ctx context.Context, decline domain.PRDecline) (domain.PrReassignRead, error)
+	GetDeclinesByUserId(ctx context.Context, userId string) ([]domain.ReviewDecline, error)
+	GetDeclinesByTeamName(ctx context.Context, teamName string) ([]domain.ReviewDecline, error)
+	SubmitReview(ctx context.Context, decision domain.ReviewDecision) (domain.ReviewDecision, domain.PullRequestRead, error)
+	GetReviewHistory(ctx context.Context, prId string) ([]domain.ReviewDecision, error)
+	Update(ctx context.Context, update domain.PRUpdate) (domain.PullRequestRead, error)
+	GetDependencyGraph(ctx context.Context, prId string) (domain.PRDependencyGraph, error)
+	PushRevision(ctx context.Context, revision domain.Revision) (domain.RevisionPush, error)
+	GetRevisions(ctx context.Context, prId string) ([]domain.Revision, error)
+	GetTimeline(ctx context.Context, prId string) ([]domain.PREvent, error)
+	Ready(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error)
+	Close(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error)
+	Reopen(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error)
 }
 
 type PRService struct {
-	prRepo   repositories.PrRepo
-	userRepo repositories.UserRepo
-	teamRepo repositories.TeamRepo
-	tm       *transaction.Manager
+	prRepo        repositories.PrRepo
+	userRepo      repositories.UserRepo
+	teamRepo      repositories.TeamRepo
+	codeOwnerRepo repositories.CodeOwnerRepo
+	ruleRepo      repositories.ReviewerRuleRepo
+	absenceRepo   repositories.AbsenceRepo
+	declineRepo   repositories.DeclineRepo
+	revisionRepo  repositories.RevisionRepo
+	eventRepo     repositories.EventRepo
+	selectors     *SelectorRegistry
+	clock         Clock
+	tm            transaction.Runner
 }
 
-func NewPRService(prRepo repositories.PrRepo, userRepo repositories.UserRepo, teamRepo repositories.TeamRepo, tm *transaction.Manager) PRService {
-	return PRService{prRepo: prRepo, userRepo: userRepo, teamRepo: teamRepo, tm: tm}
+func NewPRService(prRepo repositories.PrRepo, userRepo repositories.UserRepo, teamRepo repositories.TeamRepo, codeOwnerRepo repositories.CodeOwnerRepo, ruleRepo repositories.ReviewerRuleRepo, absenceRepo repositories.AbsenceRepo, declineRepo repositories.DeclineRepo, revisionRepo repositories.RevisionRepo, eventRepo repositories.EventRepo, selectors *SelectorRegistry, clock Clock, tm transaction.Runner) PRService {
+	return PRService{prRepo: prRepo, userRepo: userRepo, teamRepo: teamRepo, codeOwnerRepo: codeOwnerRepo, ruleRepo: ruleRepo, absenceRepo: absenceRepo, declineRepo: declineRepo, revisionRepo: revisionRepo, eventRepo: eventRepo, selectors: selectors, clock: clock, tm: tm}
 }
 
 func (s *PRService) Create(ctx context.Context, createPr domain.PullRequestCreate) (domain.PullRequestRead, error) {
@@ -48,23 +73,50 @@ func (s *PRService) Create(ctx context.Context, createPr domain.PullRequestCreat
 			return err
 		}
 
-		pr, err = s.prRepo.Create(ctx, createPr)
+		team, err := s.teamRepo.GetByName(ctx, author.TeamName)
 		if err != nil {
 			return err
 		}
 
-		users, err := s.userRepo.GetNewReviewers(ctx, author.TeamName, author.Id)
+		if err := s.checkParents(ctx, createPr.Id, createPr.ParentIds); err != nil {
+			return err
+		}
+
+		if createPr.Draft {
+			if len(createPr.ReviewerIds) > 0 {
+				return validateError.DraftWithReviewers
+			}
+			pr, err = s.prRepo.Create(ctx, createPr)
+			if err != nil {
+				return err
+			}
+			if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: pr.Id, Kind: domain.EventCreated, ActorId: author.Id}); err != nil {
+				return err
+			}
+			pr.Reviewers = []domain.AssignedReviewer{}
+			pr.AssignReviewerIds = []string{}
+			return s.prRepo.AddParents(ctx, pr.Id, createPr.ParentIds)
+		}
+
+		manual, err := s.manualReviewers(ctx, author, team, createPr.ReviewerIds)
 		if err != nil {
 			return err
 		}
 
-		reviewerIds, err := s.prRepo.AssignReviewers(ctx, createPr.Id, users)
+		pr, err = s.prRepo.Create(ctx, createPr)
 		if err != nil {
 			return err
 		}
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: pr.Id, Kind: domain.EventCreated, ActorId: author.Id}); err != nil {
+			return err
+		}
 
-		pr.AssignReviewerIds = reviewerIds
-		return nil
+		if err := s.prRepo.AddParents(ctx, pr.Id, createPr.ParentIds); err != nil {
+			return err
+		}
+
+		pr, err = s.assignInitial(ctx, author, team, pr, createPr.ChangedFiles, manual, nil)
+		return err
 	})
 
 	if err != nil {
@@ -73,6 +125,51 @@ func (s *PRService) Create(ctx context.Context, createPr domain.PullRequestCreat
 	return pr, nil
 }
 
+// assignInitial назначает ревьюеров только что открытому PR: ручные занимают места первыми,
+// остальные добираются автоматически. Ревьюеры excluded не рассматриваются.
+func (s *PRService) assignInitial(ctx context.Context, author domain.User, team domain.Team, pr domain.PullRequestRead, changedFiles []string, manual []domain.AssignedReviewer, excluded []string) (domain.PullRequestRead, error) {
+	pools, err := s.loadPools(ctx, author, team, changedFiles, append(domain.ReviewerIds(manual), excluded...))
+	if err != nil {
+		return pr, err
+	}
+
+	free := max(team.ReviewersCount-len(manual), 0)
+	users, err := s.pickReviewers(ctx, author, team, pools, free)
+	if err != nil {
+		return pr, err
+	}
+
+	seniorPresent, err := s.hasSenior(ctx, team, domain.ReviewerIds(manual), "")
+	if err != nil {
+		return pr, err
+	}
+	if !seniorPresent {
+		users, err = s.ensureSenior(ctx, author, team, pools, users, free)
+		if err != nil {
+			return pr, err
+		}
+	}
+	users = append(manual, users...)
+
+	if len(users) < team.MinReviewers {
+		return pr, validateError.NotEnoughReviewers
+	}
+
+	reviewers, err := s.prRepo.AssignReviewers(ctx, pr.Id, users)
+	if err != nil {
+		return pr, err
+	}
+	if err := s.recordAssigned(ctx, pr.Id, reviewers); err != nil {
+		return pr, err
+	}
+
+	pr.Reviewers = reviewers
+	pr.AssignReviewerIds = domain.ReviewerIds(reviewers)
+	return pr, nil
+}
+
+// Merge проверяет политику слияния команды автора. Повторное слияние уже слитого PR политику не проверяет,
+// а Force от администратора пропускает невыполненные условия и фиксирует это для аудита.
 func (s *PRService) Merge(ctx context.Context, prMerger domain.PRMerge) (domain.PRMergeRead, error) {
 	var pr domain.PRMergeRead
 	err := s.tm.Do(ctx, func(ctx context.Context) error {
@@ -81,18 +178,75 @@ func (s *PRService) Merge(ctx context.Context, prMerger domain.PRMerge) (domain.
 			return err
 		}
 
-		prMerged, err := s.prRepo.Merge(ctx, prMerger.Id)
+		reviewers, err := s.prRepo.GetReviewersById(ctx, currentPr.Id)
 		if err != nil {
 			return err
 		}
 
-		users, err := s.prRepo.GetReviewersById(ctx, currentPr.Id)
+		var forcedBy string
+		var unmet []domain.MergeCondition
+		if currentPr.Status != domain.StatusMerged {
+			if !currentPr.Status.CanTransitionTo(domain.StatusMerged) {
+				return validateError.InvalidStatusTransition
+			}
+
+			// Порядок слияния стека — не политика команды, Force его не обходит
+			graph, err := s.prRepo.GetDependencyGraph(ctx, currentPr.Id)
+			if err != nil {
+				return err
+			}
+			if chain := graph.BlockingChain(currentPr.Id); len(chain) > 0 {
+				return &validateError.MergeBlockedError{Unmet: []domain.MergeCondition{{Kind: domain.ConditionParentsMerged, PullRequestIds: chain}}}
+			}
+
+			author, err := s.userRepo.GetById(ctx, currentPr.AuthorId)
+			if err != nil {
+				return err
+			}
+
+			team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+			if err != nil {
+				return err
+			}
+
+			unmet = team.UnmetMergeConditions(author.Id, reviewers)
+			if prMerger.Force {
+				admin, err := s.userRepo.GetById(ctx, prMerger.UserId)
+				if err != nil {
+					return err
+				}
+				if !admin.IsAdmin {
+					return validateError.ForceMergeNotAllowed
+				}
+				if err := s.prRepo.RecordForcedMerge(ctx, currentPr.Id, admin.Id, unmet); err != nil {
+					return err
+				}
+				forcedBy = admin.Id
+			} else if len(unmet) > 0 {
+				return &validateError.MergeBlockedError{Unmet: unmet}
+			}
+		}
+
+		prMerged, err := s.prRepo.Merge(ctx, prMerger.Id)
 		if err != nil {
 			return err
 		}
 
+		if currentPr.Status != domain.StatusMerged {
+			event := domain.PREvent{PullRequestId: currentPr.Id, Kind: domain.EventMerged, ActorId: forcedBy}
+			if forcedBy != "" {
+				event.Details = "forced"
+			}
+			if err := s.recordEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+
 		pr = prMerged
-		pr.AssignReviewerIds = users
+		pr.Reviewers = reviewers
+		pr.AssignReviewerIds = domain.ReviewerIds(reviewers)
+		pr.ForcedBy = forcedBy
+		pr.Unmet = unmet
 		return nil
 	})
 
@@ -111,8 +265,8 @@ func (s *PRService) Reassign(ctx context.Context, pr domain.PRReassign) (domain.
 		if err != nil {
 			return err
 		}
-		if currentPR.Status == domain.StatusMerged {
-			return validateError.PrMergedExist
+		if err := requireOpen(currentPR); err != nil {
+			return err
 		}
 
 		author, err := s.userRepo.GetById(ctx, currentPR.AuthorId)
@@ -125,50 +279,92 @@ func (s *PRService) Reassign(ctx context.Context, pr domain.PRReassign) (domain.
 			return err
 		}
 
-		if author.TeamName != oldReviewer.TeamName {
-			return validateError.UserNotAssignToTeam
-		}
-
-		reviewerIds, err := s.prRepo.GetReviewersById(ctx, pr.Id)
+		reviewers, err := s.prRepo.GetReviewersById(ctx, pr.Id)
 		if err != nil {
 			return err
 		}
 
-		found := false
-		for _, id := range reviewerIds {
-			if id == oldReviewer.Id {
-				found = true
+		var oldAssignment *domain.AssignedReviewer
+		for i := range reviewers {
+			if reviewers[i].Id == oldReviewer.Id {
+				oldAssignment = &reviewers[i]
 				break
 			}
 		}
 
-		if !found {
+		// Ревьюер из резервного пула может быть из другой команды
+		if author.TeamName != oldReviewer.TeamName && (oldAssignment == nil || !oldAssignment.External) {
+			return validateError.UserNotAssignToTeam
+		}
+
+		if oldAssignment == nil {
 			return validateError.UserNotAssignReviewer
 		}
 
-		newReviewerId, err := s.userRepo.GetNewReviewer(ctx, author.Id, author.TeamName, reviewerIds)
+		team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		// Отказавшиеся от PR ревьюеры больше на него не назначаются
+		declined, err := s.declineRepo.GetReviewerIdsByPR(ctx, pr.Id)
+		if err != nil {
+			return err
+		}
+
+		// Единственного старшего ревьюера можно заменить только старшим
+		seniorRequired := false
+		if team.IsSenior(oldReviewer.Level) {
+			seniorLeft, err := s.hasSenior(ctx, team, domain.ReviewerIds(reviewers), oldReviewer.Id)
+			if err != nil {
+				return err
+			}
+			seniorRequired = !seniorLeft
+		}
+
+		var newReviewers []domain.AssignedReviewer
+		if pr.NewUserId != "" {
+			newReviewers, err = s.requestedReplacement(ctx, author, team, pr.NewUserId, reviewers, declined, seniorRequired)
+		} else {
+			newReviewers, err = s.automaticReplacement(ctx, author, team, reviewers, declined, seniorRequired)
+		}
 		if err != nil {
 			return err
 		}
 
-		err = s.prRepo.Reassign(ctx, pr.Id, newReviewerId, pr.OldUserId)
+		// Пустой результат означает политику leave_empty: слот освобождается без замены
+		var newReviewerId string
+		event := domain.PREvent{PullRequestId: pr.Id, Kind: domain.EventReviewerRemoved, FromUserId: pr.OldUserId}
+		if len(newReviewers) == 0 {
+			err = s.prRepo.RemoveReviewer(ctx, pr.Id, pr.OldUserId)
+		} else {
+			newReviewerId = newReviewers[0].Id
+			event.Kind = domain.EventReassigned
+			event.ToUserId = newReviewerId
+			err = s.prRepo.Reassign(ctx, pr.Id, newReviewers[0], pr.OldUserId)
+		}
 		if err != nil {
 			return err
 		}
+		if err := s.recordEvent(ctx, event); err != nil {
+			return err
+		}
 
 		currentPR, err = s.prRepo.GetById(ctx, pr.Id)
 		if err != nil {
 			return err
 		}
 
-		reviewerIds, err = s.prRepo.GetReviewersById(ctx, pr.Id)
+		reviewers, err = s.prRepo.GetReviewersById(ctx, pr.Id)
 		if err != nil {
 			return err
 		}
 
 		prReassign.ReplacedId = newReviewerId
+		prReassign.Requested = pr.NewUserId != ""
 		prReassign.PullRequest = currentPR
-		prReassign.PullRequest.AssignReviewerIds = reviewerIds
+		prReassign.PullRequest.Reviewers = reviewers
+		prReassign.PullRequest.AssignReviewerIds = domain.ReviewerIds(reviewers)
 
 		return nil
 
@@ -181,3 +377,50 @@ func (s *PRService) Reassign(ctx context.Context, pr domain.PRReassign) (domain.
 	return prReassign, nil
 
 }
+
+func (s *PRService) automaticReplacement(ctx context.Context, author domain.User, team domain.Team, reviewers []domain.AssignedReviewer, declined []string, seniorRequired bool) ([]domain.AssignedReviewer, error) {
+	pools, err := s.loadPools(ctx, author, team, nil, append(domain.ReviewerIds(reviewers), declined...))
+	if err != nil {
+		return nil, err
+	}
+	if poolsEmpty(pools) {
+		return nil, validateError.NoCandidate
+	}
+
+	if seniorRequired {
+		pools = filterPools(pools, func(c domain.ReviewerCandidate) bool { return team.IsSenior(c.Level) })
+		if poolsEmpty(pools) {
+			return nil, validateError.NoSeniorCandidate
+		}
+	}
+
+	return s.pickReviewers(ctx, author, team, pools, 1)
+}
+
+// requestedReplacement проверяет явно запрошенную замену по тем же правилам, что и ручное добавление ревьюера,
+// включая запрет на ревью автора и текущее отсутствие.
+func (s *PRService) requestedReplacement(ctx context.Context, author domain.User, team domain.Team, userId string, reviewers []domain.AssignedReviewer, declined []string, seniorRequired bool) ([]domain.AssignedReviewer, error) {
+	if containsReviewer(reviewers, userId) {
+		return nil, validateError.ReviewerAlreadyAssigned
+	}
+	if slices.Contains(declined, userId) {
+		return nil, validateError.ReviewerDeclined
+	}
+
+	reviewer, err := s.checkManualReviewer(ctx, author, team, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if seniorRequired {
+		senior, err := s.hasSenior(ctx, team, []string{userId}, "")
+		if err != nil {
+			return nil, err
+		}
+		if !senior {
+			return nil, validateError.SeniorReviewerRequired
+		}
+	}
+
+	return []domain.AssignedReviewer{reviewer}, nil
+}
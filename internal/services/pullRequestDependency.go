@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// checkParents проверяет родителей нового PR: они существуют, и ни один из них не зависит от самого PR.
+func (s *PRService) checkParents(ctx context.Context, prId string, parentIds []string) error {
+	for _, parentId := range parentIds {
+		if parentId == prId {
+			return validateError.DependencyCycle
+		}
+
+		if _, err := s.prRepo.GetById(ctx, parentId); err != nil {
+			if errors.Is(err, validateError.ErrPrNotExist) {
+				return validateError.InvalidParent
+			}
+			return err
+		}
+
+		graph, err := s.prRepo.GetDependencyGraph(ctx, parentId)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(graph.Ancestors(parentId), prId) {
+			return validateError.DependencyCycle
+		}
+	}
+	return nil
+}
+
+func (s *PRService) GetDependencyGraph(ctx context.Context, prId string) (domain.PRDependencyGraph, error) {
+	if _, err := s.prRepo.GetById(ctx, prId); err != nil {
+		return domain.PRDependencyGraph{}, err
+	}
+
+	return s.prRepo.GetDependencyGraph(ctx, prId)
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+)
+
+// recordEvent дописывает событие в ленту PR. Вызывается внутри транзакции изменения,
+// поэтому откат изменения откатывает и событие.
+func (s *PRService) recordEvent(ctx context.Context, event domain.PREvent) error {
+	return s.eventRepo.Create(ctx, event)
+}
+
+func (s *PRService) recordAssigned(ctx context.Context, prId string, reviewers []domain.AssignedReviewer) error {
+	for _, r := range reviewers {
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: prId, Kind: domain.EventReviewerAssigned, ToUserId: r.Id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PRService) GetTimeline(ctx context.Context, prId string) ([]domain.PREvent, error) {
+	if _, err := s.prRepo.GetById(ctx, prId); err != nil {
+		return nil, err
+	}
+
+	return s.eventRepo.GetByPullRequestId(ctx, prId)
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// requireOpen пропускает только открытые PR: слитый и черновик/закрытый отличаются ошибкой.
+func requireOpen(pr domain.PullRequestRead) error {
+	switch pr.Status {
+	case domain.StatusOpen:
+		return nil
+	case domain.StatusMerged:
+		return validateError.PrMergedExist
+	default:
+		return validateError.PrNotOpen
+	}
+}
+
+// Ready переводит черновик в OPEN и назначает ревьюеров так же, как при создании.
+func (s *PRService) Ready(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error) {
+	return s.open(ctx, change, domain.StatusDraft, domain.EventReady)
+}
+
+// Reopen возвращает закрытый PR в OPEN с новым набором ревьюеров; отказавшиеся ранее не назначаются.
+func (s *PRService) Reopen(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error) {
+	return s.open(ctx, change, domain.StatusClosed, domain.EventReopened)
+}
+
+func (s *PRService) open(ctx context.Context, change domain.PRStatusChange, from domain.PullRequestStatus, kind domain.PREventKind) (domain.PullRequestRead, error) {
+	var pr domain.PullRequestRead
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		current, err := s.prRepo.GetById(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+		if current.Status != from || !current.Status.CanTransitionTo(domain.StatusOpen) {
+			return validateError.InvalidStatusTransition
+		}
+
+		author, err := s.userRepo.GetById(ctx, current.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		declined, err := s.declineRepo.GetReviewerIdsByPR(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+
+		pr, err = s.prRepo.SetStatus(ctx, change.Id, domain.StatusOpen)
+		if err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: pr.Id, Kind: kind}); err != nil {
+			return err
+		}
+
+		pr, err = s.assignInitial(ctx, author, team, pr, change.ChangedFiles, nil, declined)
+		return err
+	})
+
+	if err != nil {
+		return domain.PullRequestRead{}, err
+	}
+	return pr, nil
+}
+
+// Close закрывает PR без слияния и освобождает его ревьюеров.
+func (s *PRService) Close(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error) {
+	var pr domain.PullRequestRead
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		current, err := s.prRepo.GetById(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+		if !current.Status.CanTransitionTo(domain.StatusClosed) {
+			return validateError.InvalidStatusTransition
+		}
+
+		reviewers, err := s.prRepo.GetReviewersById(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+		if err := s.prRepo.RemoveReviewers(ctx, change.Id); err != nil {
+			return err
+		}
+		for _, r := range reviewers {
+			if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: change.Id, Kind: domain.EventReviewerRemoved, FromUserId: r.Id}); err != nil {
+				return err
+			}
+		}
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: change.Id, Kind: domain.EventClosed}); err != nil {
+			return err
+		}
+
+		pr, err = s.prRepo.SetStatus(ctx, change.Id, domain.StatusClosed)
+		if err != nil {
+			return err
+		}
+
+		pr.Reviewers = []domain.AssignedReviewer{}
+		pr.AssignReviewerIds = []string{}
+		return nil
+	})
+
+	if err != nil {
+		return domain.PullRequestRead{}, err
+	}
+	return pr, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// Update меняет название, описание и ссылку PR и передаёт авторство.
+// При смене автора ревьюеры открытого PR проверяются заново относительно нового автора.
+func (s *PRService) Update(ctx context.Context, update domain.PRUpdate) (domain.PullRequestRead, error) {
+	var pr domain.PullRequestRead
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		current, err := s.prRepo.GetById(ctx, update.Id)
+		if err != nil {
+			return err
+		}
+		if current.Status == domain.StatusMerged {
+			return validateError.PrMergedExist
+		}
+
+		authorChanged := update.AuthorId != nil && *update.AuthorId != current.AuthorId
+		if authorChanged {
+			newAuthor, err := s.userRepo.GetById(ctx, *update.AuthorId)
+			if err != nil {
+				return err
+			}
+			if !newAuthor.IsActive {
+				return validateError.AuthorInactive
+			}
+		}
+
+		pr, err = s.prRepo.Update(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		if update.HasDetails() {
+			if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: pr.Id, Kind: domain.EventUpdated}); err != nil {
+				return err
+			}
+		}
+		if authorChanged {
+			event := domain.PREvent{PullRequestId: pr.Id, Kind: domain.EventAuthorChanged, FromUserId: current.AuthorId, ToUserId: pr.AuthorId}
+			if err := s.recordEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if authorChanged && pr.Status == domain.StatusOpen {
+			if err := s.reassessReviewers(ctx, pr); err != nil {
+				return err
+			}
+		}
+
+		pr, err = s.withReviewers(ctx, pr)
+		return err
+	})
+
+	if err != nil {
+		return domain.PullRequestRead{}, err
+	}
+	return pr, nil
+}
+
+// reassessReviewers приводит ревьюеров PR в соответствие с новым автором: пересчитывает External
+// и заменяет по обычным правилам переназначения тех, кто больше не может ревьюить.
+// External обновляется до замены, иначе Reassign отклонит ревьюера не из команды автора.
+func (s *PRService) reassessReviewers(ctx context.Context, pr domain.PullRequestRead) error {
+	author, err := s.userRepo.GetById(ctx, pr.AuthorId)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+	if err != nil {
+		return err
+	}
+
+	reviewers, err := s.prRepo.GetReviewersById(ctx, pr.Id)
+	if err != nil {
+		return err
+	}
+
+	teamOf := make(map[string]string, len(reviewers))
+	for _, r := range reviewers {
+		user, err := s.userRepo.GetById(ctx, r.Id)
+		if err != nil {
+			return err
+		}
+		teamOf[r.Id] = user.TeamName
+	}
+
+	rules, err := s.ruleRepo.GetByUserId(ctx, author.Id)
+	if err != nil {
+		return err
+	}
+
+	reassessed, invalid := team.ReassessReviewers(author.Id, reviewers, teamOf, rules)
+	for i, r := range reassessed {
+		if r.External != reviewers[i].External {
+			if err := s.prRepo.SetReviewerExternal(ctx, pr.Id, r.Id, r.External); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, id := range invalid {
+		if _, err := s.replaceReviewer(ctx, pr.Id, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
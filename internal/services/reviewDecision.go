@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// SubmitReview сохраняет решение назначенного ревьюера. Предыдущие решения остаются в истории,
+// а в ответах по PR показывается только последнее.
+func (s *PRService) SubmitReview(ctx context.Context, decision domain.ReviewDecision) (domain.ReviewDecision, domain.PullRequestRead, error) {
+	var saved domain.ReviewDecision
+	var pr domain.PullRequestRead
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		currentPR, err := s.prRepo.GetById(ctx, decision.PullRequestId)
+		if err != nil {
+			return err
+		}
+		if err := requireOpen(currentPR); err != nil {
+			return err
+		}
+		// Автор не может одобрить собственный PR
+		if decision.ReviewerId == currentPR.AuthorId {
+			return validateError.ReviewerIsAuthor
+		}
+
+		if _, err := s.userRepo.GetById(ctx, decision.ReviewerId); err != nil {
+			return err
+		}
+
+		reviewers, err := s.prRepo.GetReviewersById(ctx, decision.PullRequestId)
+		if err != nil {
+			return err
+		}
+		if !containsReviewer(reviewers, decision.ReviewerId) {
+			return validateError.UserNotAssignReviewer
+		}
+
+		saved, err = s.prRepo.AddDecision(ctx, decision)
+		if err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: saved.PullRequestId, Kind: domain.EventReviewSubmitted, ActorId: saved.ReviewerId, Details: string(saved.Decision)}); err != nil {
+			return err
+		}
+
+		pr, err = s.withReviewers(ctx, currentPR)
+		return err
+	})
+
+	if err != nil {
+		return domain.ReviewDecision{}, domain.PullRequestRead{}, err
+	}
+	return saved, pr, nil
+}
+
+func (s *PRService) GetReviewHistory(ctx context.Context, prId string) ([]domain.ReviewDecision, error) {
+	if _, err := s.prRepo.GetById(ctx, prId); err != nil {
+		return nil, err
+	}
+
+	return s.prRepo.GetDecisionsById(ctx, prId)
+}
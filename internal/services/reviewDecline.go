@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// Decline фиксирует отказ ревьюера и переназначает его место так же, как Reassign.
+// Если замены нет, отказ всё равно принимается, а место остаётся свободным.
+func (s *PRService) Decline(ctx context.Context, decline domain.PRDecline) (domain.PrReassignRead, error) {
+	var res domain.PrReassignRead
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		currentPR, _, _, err := s.loadOpenPR(ctx, decline.Id)
+		if err != nil {
+			return err
+		}
+
+		reviewers, err := s.prRepo.GetReviewersById(ctx, decline.Id)
+		if err != nil {
+			return err
+		}
+		if !containsReviewer(reviewers, decline.UserId) {
+			return validateError.UserNotAssignReviewer
+		}
+
+		if err := s.declineRepo.Create(ctx, decline); err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: decline.Id, Kind: domain.EventReviewDeclined, ActorId: decline.UserId, Details: string(decline.Reason)}); err != nil {
+			return err
+		}
+
+		res.ReplacedId, err = s.replaceReviewer(ctx, decline.Id, decline.UserId)
+		if err != nil {
+			return err
+		}
+
+		res.PullRequest, err = s.withReviewers(ctx, currentPR)
+		return err
+	})
+
+	if err != nil {
+		return domain.PrReassignRead{}, err
+	}
+	return res, nil
+}
+
+// replaceReviewer переназначает место ревьюера через Reassign; если подходящей замены нет,
+// место просто освобождается. Возвращает id нового ревьюера или пустую строку.
+func (s *PRService) replaceReviewer(ctx context.Context, prId string, userId string) (string, error) {
+	res, err := s.Reassign(ctx, domain.PRReassign{Id: prId, OldUserId: userId})
+	if err == nil {
+		return res.ReplacedId, nil
+	}
+	if !errors.Is(err, validateError.NoCandidate) && !errors.Is(err, validateError.ReviewersAtCapacity) && !errors.Is(err, validateError.NoSeniorCandidate) {
+		return "", err
+	}
+
+	if err := s.prRepo.RemoveReviewer(ctx, prId, userId); err != nil {
+		return "", err
+	}
+	return "", s.recordEvent(ctx, domain.PREvent{PullRequestId: prId, Kind: domain.EventReviewerRemoved, FromUserId: userId})
+}
+
+func (s *PRService) GetDeclinesByUserId(ctx context.Context, userId string) ([]domain.ReviewDecline, error) {
+	if _, err := s.userRepo.GetById(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	return s.declineRepo.GetByUserId(ctx, userId)
+}
+
+func (s *PRService) GetDeclinesByTeamName(ctx context.Context, teamName string) ([]domain.ReviewDecline, error) {
+	if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	return s.declineRepo.GetByTeamName(ctx, teamName)
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// candidatePool — кандидаты, выбираемые стратегией команды teamName.
+// matchedRules заполняется для владельцев кода и хранит сработавший шаблон.
+type candidatePool struct {
+	teamName     string
+	candidates   []domain.ReviewerCandidate
+	matchedRules map[string]string
+}
+
+func (p candidatePool) split(picked []domain.AssignedReviewer) (available, saturated []domain.ReviewerCandidate) {
+	for _, c := range p.candidates {
+		if containsReviewer(picked, c.Id) {
+			continue
+		}
+		if c.AtCapacity() {
+			saturated = append(saturated, c)
+		} else {
+			available = append(available, c)
+		}
+	}
+	return available, saturated
+}
+
+// loadPools собирает пулы в порядке приоритета: владельцы изменённых путей,
+// команда автора и резервные команды. Текущие ревьюеры PR в пулы не попадают.
+func (s *PRService) loadPools(ctx context.Context, author domain.User, team domain.Team, changedFiles []string, reviewerIds []string) ([]candidatePool, error) {
+	pools := make([]candidatePool, 0, len(team.FallbackTeams)+2)
+	if reviewerIds == nil {
+		reviewerIds = []string{}
+	}
+
+	if len(changedFiles) > 0 {
+		ownerPool, err := s.loadOwnerPool(ctx, author, changedFiles, reviewerIds)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, ownerPool)
+	}
+
+	for _, teamName := range append([]string{author.TeamName}, team.FallbackTeams...) {
+		candidates, err := s.teamCandidates(ctx, author, teamName, reviewerIds)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, candidatePool{teamName: teamName, candidates: candidates})
+	}
+
+	return pools, nil
+}
+
+func (s *PRService) teamCandidates(ctx context.Context, author domain.User, teamName string, reviewerIds []string) ([]domain.ReviewerCandidate, error) {
+	if len(reviewerIds) == 0 {
+		return s.userRepo.GetNewReviewers(ctx, teamName, author.Id, s.clock.Now())
+	}
+	return s.userRepo.GetNewReviewer(ctx, author.Id, teamName, reviewerIds, s.clock.Now())
+}
+
+func (s *PRService) loadOwnerPool(ctx context.Context, author domain.User, changedFiles []string, reviewerIds []string) (candidatePool, error) {
+	pool := candidatePool{teamName: author.TeamName, matchedRules: make(map[string]string)}
+
+	rules, err := s.codeOwnerRepo.GetRulesByTeamName(ctx, author.TeamName)
+	if err != nil {
+		return pool, err
+	}
+
+	userIds := make([]string, 0)
+	for _, rule := range MatchCodeOwners(rules, changedFiles) {
+		for _, token := range rule.Owners {
+			owner := domain.ParseCodeOwner(token)
+			if owner.TeamName == "" {
+				if _, ok := pool.matchedRules[owner.UserId]; !ok {
+					pool.matchedRules[owner.UserId] = rule.Pattern
+					userIds = append(userIds, owner.UserId)
+				}
+				continue
+			}
+
+			members, err := s.teamCandidates(ctx, author, owner.TeamName, reviewerIds)
+			if err != nil {
+				return pool, err
+			}
+			for _, m := range members {
+				if _, ok := pool.matchedRules[m.Id]; !ok {
+					pool.matchedRules[m.Id] = rule.Pattern
+					pool.candidates = append(pool.candidates, m)
+				}
+			}
+		}
+	}
+
+	if len(userIds) == 0 {
+		return pool, nil
+	}
+
+	users, err := s.userRepo.GetCandidatesByIds(ctx, userIds, author.Id, s.clock.Now())
+	if err != nil {
+		return pool, err
+	}
+	for _, u := range users {
+		if !slices.Contains(reviewerIds, u.Id) {
+			pool.candidates = append(pool.candidates, u)
+		}
+	}
+
+	return pool, nil
+}
+
+func poolsEmpty(pools []candidatePool) bool {
+	for _, p := range pools {
+		if len(p.candidates) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pickReviewers сначала берёт свободных кандидатов из пулов по порядку,
+// а если мест не хватает — поступает с перегруженными по политике команды.
+// Кандидаты не из команды автора помечаются как внешние.
+func (s *PRService) pickReviewers(ctx context.Context, author domain.User, team domain.Team, pools []candidatePool, count int) ([]domain.AssignedReviewer, error) {
+	picked := make([]domain.AssignedReviewer, 0, count)
+	saturatedPools := make([]candidatePool, 0, len(pools))
+
+	for _, pool := range pools {
+		available, saturated := pool.split(picked)
+		if len(saturated) > 0 {
+			saturatedPools = append(saturatedPools, candidatePool{teamName: pool.teamName, candidates: saturated, matchedRules: pool.matchedRules})
+		}
+
+		missing := count - len(picked)
+		if missing <= 0 || len(available) == 0 {
+			continue
+		}
+
+		ids, err := s.selectPreferred(ctx, pool.teamName, author.Id, available, missing)
+		if err != nil {
+			return nil, err
+		}
+		picked = appendAssigned(picked, author, pool, ids)
+	}
+
+	if len(picked) == count || len(saturatedPools) == 0 {
+		return picked, nil
+	}
+
+	switch team.CapacityPolicy {
+	case domain.CapacityReject:
+		return nil, validateError.ReviewersAtCapacity
+	case domain.CapacityLeaveEmpty:
+		return picked, nil
+	}
+
+	for _, pool := range saturatedPools {
+		missing := count - len(picked)
+		if missing <= 0 {
+			break
+		}
+
+		candidates := make([]domain.ReviewerCandidate, 0, len(pool.candidates))
+		for _, c := range pool.candidates {
+			if !containsReviewer(picked, c.Id) {
+				candidates = append(candidates, c)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		ids, err := s.selectPreferred(ctx, pool.teamName, author.Id, candidates, missing)
+		if err != nil {
+			return nil, err
+		}
+		picked = appendAssigned(picked, author, pool, ids)
+	}
+
+	return picked, nil
+}
+
+func appendAssigned(picked []domain.AssignedReviewer, author domain.User, pool candidatePool, ids []string) []domain.AssignedReviewer {
+	teams := make(map[string]string, len(pool.candidates))
+	for _, c := range pool.candidates {
+		teams[c.Id] = c.TeamName
+	}
+
+	for _, id := range ids {
+		picked = append(picked, domain.AssignedReviewer{
+			Id:          id,
+			External:    teams[id] != author.TeamName,
+			MatchedRule: pool.matchedRules[id],
+		})
+	}
+	return picked
+}
+
+func containsReviewer(reviewers []domain.AssignedReviewer, id string) bool {
+	for _, r := range reviewers {
+		if r.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SoonWorkWindow — насколько заранее до начала рабочего дня кандидат считается доступным.
+const SoonWorkWindow = time.Hour
+
+// SplitByWorkingHours делит кандидатов на тех, кто сейчас работает или скоро начнёт, и остальных.
+func SplitByWorkingHours(candidates []domain.ReviewerCandidate, now time.Time) (onDuty, offDuty []domain.ReviewerCandidate) {
+	for _, c := range candidates {
+		if c.Schedule.UntilWork(now) <= SoonWorkWindow {
+			onDuty = append(onDuty, c)
+		} else {
+			offDuty = append(offDuty, c)
+		}
+	}
+	return onDuty, offDuty
+}
+
+// selectPreferred сначала отдаёт места кандидатам с правилом предпочтения к автору,
+// оставшиеся заполняются стратегией из остальных. Внутри каждой группы
+// вперёд идут кандидаты в рабочих часах.
+func (s *PRService) selectPreferred(ctx context.Context, teamName string, authorId string, candidates []domain.ReviewerCandidate, count int) ([]string, error) {
+	preferred := make([]domain.ReviewerCandidate, 0)
+	others := make([]domain.ReviewerCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Preferred {
+			preferred = append(preferred, c)
+		} else {
+			others = append(others, c)
+		}
+	}
+
+	now := s.clock.Now()
+	preferredOnDuty, preferredOffDuty := SplitByWorkingHours(preferred, now)
+	othersOnDuty, othersOffDuty := SplitByWorkingHours(others, now)
+
+	ids := make([]string, 0, count)
+	for _, group := range [][]domain.ReviewerCandidate{preferredOnDuty, preferredOffDuty, othersOnDuty, othersOffDuty} {
+		missing := count - len(ids)
+		if missing <= 0 || len(group) == 0 {
+			continue
+		}
+
+		selected, err := s.selectReviewers(ctx, teamName, authorId, group, missing)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, selected...)
+	}
+
+	return ids, nil
+}
+
+func (s *PRService) selectReviewers(ctx context.Context, teamName string, authorId string, candidates []domain.ReviewerCandidate, count int) ([]string, error) {
+	selector, err := s.selectors.ForTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return selector.Select(ctx, SelectRequest{
+		TeamName:   teamName,
+		AuthorId:   authorId,
+		Candidates: candidates,
+		Count:      count,
+	})
+}
+
+// ensureSenior добивается политики команды «хотя бы один старший ревьюер», если это возможно.
+// Старший кандидат занимает свободное место либо вытесняет последнего выбранного не по правилу владения кодом.
+func (s *PRService) ensureSenior(ctx context.Context, author domain.User, team domain.Team, pools []candidatePool, picked []domain.AssignedReviewer, count int) ([]domain.AssignedReviewer, error) {
+	if !team.RequiresSenior() || count <= 0 {
+		return picked, nil
+	}
+
+	levels := make(map[string]int)
+	for _, pool := range pools {
+		for _, c := range pool.candidates {
+			levels[c.Id] = c.Level
+		}
+	}
+	for _, r := range picked {
+		if team.IsSenior(levels[r.Id]) {
+			return picked, nil
+		}
+	}
+
+	seniorPools := filterPools(pools, func(c domain.ReviewerCandidate) bool { return team.IsSenior(c.Level) })
+
+	var senior []domain.AssignedReviewer
+	for _, saturated := range []bool{false, true} {
+		if saturated && team.CapacityPolicy != domain.CapacityOverAssign {
+			break
+		}
+		for _, pool := range seniorPools {
+			available, full := pool.split(picked)
+			if saturated {
+				available = full
+			}
+			if len(available) == 0 {
+				continue
+			}
+
+			ids, err := s.selectPreferred(ctx, pool.teamName, author.Id, available, 1)
+			if err != nil {
+				return nil, err
+			}
+			senior = appendAssigned(nil, author, pool, ids)
+			break
+		}
+		if len(senior) > 0 {
+			break
+		}
+	}
+
+	if len(senior) == 0 {
+		return picked, nil
+	}
+	if len(picked) < count {
+		return append(picked, senior[0]), nil
+	}
+
+	replace := len(picked) - 1
+	for i := len(picked) - 1; i >= 0; i-- {
+		if picked[i].MatchedRule == "" {
+			replace = i
+			break
+		}
+	}
+	picked[replace] = senior[0]
+
+	return picked, nil
+}
+
+// hasSenior проверяет, есть ли среди ревьюеров ids, кроме exceptId, ревьюер старшего уровня.
+func (s *PRService) hasSenior(ctx context.Context, team domain.Team, ids []string, exceptId string) (bool, error) {
+	for _, id := range ids {
+		if id == exceptId {
+			continue
+		}
+		user, err := s.userRepo.GetById(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if team.IsSenior(user.Level) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func filterPools(pools []candidatePool, keep func(domain.ReviewerCandidate) bool) []candidatePool {
+	filtered := make([]candidatePool, 0, len(pools))
+	for _, pool := range pools {
+		candidates := make([]domain.ReviewerCandidate, 0, len(pool.candidates))
+		for _, c := range pool.candidates {
+			if keep(c) {
+				candidates = append(candidates, c)
+			}
+		}
+		filtered = append(filtered, candidatePool{teamName: pool.teamName, candidates: candidates, matchedRules: pool.matchedRules})
+	}
+	return filtered
+}
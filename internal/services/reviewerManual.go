@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+func (s *PRService) AddReviewer(ctx context.Context, change domain.PRReviewerChange) (domain.PullRequestRead, error) {
+	var pr domain.PullRequestRead
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		currentPR, author, team, err := s.loadOpenPR(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+
+		reviewers, err := s.prRepo.GetReviewersById(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+		if containsReviewer(reviewers, change.UserId) {
+			return validateError.ReviewerAlreadyAssigned
+		}
+
+		declined, err := s.declineRepo.GetReviewerIdsByPR(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(declined, change.UserId) {
+			return validateError.ReviewerDeclined
+		}
+
+		reviewer, err := s.checkManualReviewer(ctx, author, team, change.UserId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.prRepo.AssignReviewers(ctx, change.Id, []domain.AssignedReviewer{reviewer}); err != nil {
+			return err
+		}
+		if err := s.recordAssigned(ctx, change.Id, []domain.AssignedReviewer{reviewer}); err != nil {
+			return err
+		}
+
+		pr, err = s.withReviewers(ctx, currentPR)
+		return err
+	})
+
+	if err != nil {
+		return domain.PullRequestRead{}, err
+	}
+	return pr, nil
+}
+
+func (s *PRService) RemoveReviewer(ctx context.Context, change domain.PRReviewerChange) (domain.PullRequestRead, error) {
+	var pr domain.PullRequestRead
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		currentPR, _, team, err := s.loadOpenPR(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+
+		reviewers, err := s.prRepo.GetReviewersById(ctx, change.Id)
+		if err != nil {
+			return err
+		}
+		if !containsReviewer(reviewers, change.UserId) {
+			return validateError.UserNotAssignReviewer
+		}
+		if len(reviewers)-1 < team.MinReviewers {
+			return validateError.NotEnoughReviewers
+		}
+
+		if err := s.prRepo.RemoveReviewer(ctx, change.Id, change.UserId); err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: change.Id, Kind: domain.EventReviewerRemoved, FromUserId: change.UserId}); err != nil {
+			return err
+		}
+
+		pr, err = s.withReviewers(ctx, currentPR)
+		return err
+	})
+
+	if err != nil {
+		return domain.PullRequestRead{}, err
+	}
+	return pr, nil
+}
+
+// loadOpenPR загружает PR, который ещё можно менять, вместе с автором и его командой.
+func (s *PRService) loadOpenPR(ctx context.Context, prId string) (domain.PullRequestRead, domain.User, domain.Team, error) {
+	pr, err := s.prRepo.GetById(ctx, prId)
+	if err != nil {
+		return pr, domain.User{}, domain.Team{}, err
+	}
+	if err := requireOpen(pr); err != nil {
+		return pr, domain.User{}, domain.Team{}, err
+	}
+
+	author, err := s.userRepo.GetById(ctx, pr.AuthorId)
+	if err != nil {
+		return pr, author, domain.Team{}, err
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+	if err != nil {
+		return pr, author, team, err
+	}
+
+	return pr, author, team, nil
+}
+
+func (s *PRService) withReviewers(ctx context.Context, pr domain.PullRequestRead) (domain.PullRequestRead, error) {
+	reviewers, err := s.prRepo.GetReviewersById(ctx, pr.Id)
+	if err != nil {
+		return pr, err
+	}
+
+	pr.Reviewers = reviewers
+	pr.AssignReviewerIds = domain.ReviewerIds(reviewers)
+	return pr, nil
+}
+
+// manualReviewers проверяет явно указанных ревьюеров; они занимают места до автоматического выбора.
+func (s *PRService) manualReviewers(ctx context.Context, author domain.User, team domain.Team, userIds []string) ([]domain.AssignedReviewer, error) {
+	reviewers := make([]domain.AssignedReviewer, 0, len(userIds))
+	for _, id := range userIds {
+		if containsReviewer(reviewers, id) {
+			return nil, validateError.ReviewerAlreadyAssigned
+		}
+
+		reviewer, err := s.checkManualReviewer(ctx, author, team, id)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, reviewer)
+	}
+	return reviewers, nil
+}
+
+// checkManualReviewer допускает активных пользователей из команды автора или её резервных команд, кроме самого автора.
+// Запрет на ревью автора и текущее отсутствие проверяются так же, как при автоматическом выборе.
+func (s *PRService) checkManualReviewer(ctx context.Context, author domain.User, team domain.Team, userId string) (domain.AssignedReviewer, error) {
+	user, err := s.userRepo.GetById(ctx, userId)
+	if err != nil {
+		return domain.AssignedReviewer{}, err
+	}
+
+	if user.Id == author.Id {
+		return domain.AssignedReviewer{}, validateError.ReviewerIsAuthor
+	}
+	if !user.IsActive {
+		return domain.AssignedReviewer{}, validateError.ReviewerInactive
+	}
+
+	rules, err := s.ruleRepo.GetByUserId(ctx, user.Id)
+	if err != nil {
+		return domain.AssignedReviewer{}, err
+	}
+	if domain.ExcludesReviewer(rules, user.Id, author.Id) {
+		return domain.AssignedReviewer{}, validateError.ReviewerExcluded
+	}
+
+	absences, err := s.absenceRepo.GetByUserId(ctx, user.Id)
+	if err != nil {
+		return domain.AssignedReviewer{}, err
+	}
+	if domain.AbsentAt(absences, s.clock.Now()) {
+		return domain.AssignedReviewer{}, validateError.ReviewerAbsent
+	}
+
+	external := user.TeamName != author.TeamName
+	if external && !slices.Contains(team.FallbackTeams, user.TeamName) {
+		return domain.AssignedReviewer{}, validateError.UserNotAssignToTeam
+	}
+
+	return domain.AssignedReviewer{Id: user.Id, External: external}, nil
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// errPreviewRollback откатывает транзакцию предпросмотра, чтобы выбор не оставил следов (например, курсора ротации).
+var errPreviewRollback = errors.New("preview rollback")
+
+func (s *PRService) PreviewReviewers(ctx context.Context, createPr domain.PullRequestCreate) (domain.ReviewerPreview, error) {
+	var preview domain.ReviewerPreview
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		author, err := s.userRepo.GetById(ctx, createPr.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		// Для уже существующего PR показываем, кем были бы заполнены свободные места
+		current, err := s.prRepo.GetReviewersById(ctx, createPr.Id)
+		if err != nil {
+			return err
+		}
+		manual, err := s.manualReviewers(ctx, author, team, createPr.ReviewerIds)
+		if err != nil {
+			return err
+		}
+		for _, m := range manual {
+			if containsReviewer(current, m.Id) {
+				return validateError.ReviewerAlreadyAssigned
+			}
+		}
+		current = append(current, manual...)
+		currentIds := domain.ReviewerIds(current)
+
+		declined, err := s.declineRepo.GetReviewerIdsByPR(ctx, createPr.Id)
+		if err != nil {
+			return err
+		}
+
+		pools, err := s.loadPools(ctx, author, team, createPr.ChangedFiles, append(slices.Clone(currentIds), declined...))
+		if err != nil {
+			return err
+		}
+
+		free := max(team.ReviewersCount-len(current), 0)
+		// При политике reject создание PR отклонится целиком, поэтому предпросмотр показывает
+		// пустой список ревьюеров, а причины исключения по-прежнему объясняет
+		picked, err := s.pickReviewers(ctx, author, team, pools, free)
+		atCapacity := errors.Is(err, validateError.ReviewersAtCapacity)
+		if atCapacity {
+			picked, err = []domain.AssignedReviewer{}, nil
+		}
+		if err != nil {
+			return err
+		}
+
+		seniorPresent, err := s.hasSenior(ctx, team, currentIds, "")
+		if err != nil {
+			return err
+		}
+		if !seniorPresent && !atCapacity {
+			picked, err = s.ensureSenior(ctx, author, team, pools, picked, free)
+			if err != nil {
+				return err
+			}
+		}
+
+		preview.Reviewers = picked
+		preview.Now = s.clock.Now()
+		preview.Candidates = poolCandidates(pools)
+		preview.MinReviewersMet = !atCapacity && len(current)+len(picked) >= team.MinReviewers
+
+		preview.Excluded, err = s.explainExclusions(ctx, author, team, currentIds, declined, preview)
+		if err != nil {
+			return err
+		}
+
+		return errPreviewRollback
+	})
+
+	if err != nil && !errors.Is(err, errPreviewRollback) {
+		return domain.ReviewerPreview{}, err
+	}
+
+	return preview, nil
+}
+
+func poolCandidates(pools []candidatePool) []domain.ReviewerCandidate {
+	candidates := make([]domain.ReviewerCandidate, 0)
+	seen := make(map[string]bool)
+	for _, pool := range pools {
+		for _, c := range pool.candidates {
+			if !seen[c.Id] {
+				seen[c.Id] = true
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	return candidates
+}
+
+// explainExclusions объясняет, почему участники команды автора, резервных команд
+// и не выбранные кандидаты из пула не попали в ревьюеры.
+func (s *PRService) explainExclusions(ctx context.Context, author domain.User, team domain.Team, currentIds []string, declined []string, preview domain.ReviewerPreview) ([]domain.ExcludedMember, error) {
+	excluded := make([]domain.ExcludedMember, 0)
+	explained := make(map[string]bool)
+
+	inPool := make(map[string]domain.ReviewerCandidate, len(preview.Candidates))
+	for _, c := range preview.Candidates {
+		inPool[c.Id] = c
+	}
+
+	rules, err := s.ruleRepo.GetByUserId(ctx, author.Id)
+	if err != nil {
+		return nil, err
+	}
+	excludedByRule := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.AuthorId == author.Id && rule.Kind == domain.RuleExclude {
+			excludedByRule[rule.ReviewerId] = true
+		}
+	}
+
+	absent := make(map[string]bool)
+	now := s.clock.Now()
+
+	explain := func(userId, teamName string, isActive bool) {
+		if explained[userId] || containsReviewer(preview.Reviewers, userId) {
+			return
+		}
+		explained[userId] = true
+
+		reason := domain.ExclusionUnavailable
+		candidate, ok := inPool[userId]
+		switch {
+		case userId == author.Id:
+			reason = domain.ExclusionAuthor
+		case slices.Contains(currentIds, userId):
+			reason = domain.ExclusionAlreadyReviewing
+		case slices.Contains(declined, userId):
+			reason = domain.ExclusionDeclined
+		case !isActive:
+			reason = domain.ExclusionInactive
+		case absent[userId]:
+			reason = domain.ExclusionAbsent
+		case excludedByRule[userId]:
+			reason = domain.ExclusionByRule
+		case ok && candidate.AtCapacity():
+			reason = domain.ExclusionAtCapacity
+		case ok:
+			reason = domain.ExclusionNotSelected
+		}
+
+		excluded = append(excluded, domain.ExcludedMember{UserId: userId, TeamName: teamName, Reason: reason})
+	}
+
+	for _, teamName := range append([]string{author.TeamName}, team.FallbackTeams...) {
+		members, err := s.userRepo.GetUserByTeamName(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		absences, err := s.absenceRepo.GetActualByTeamName(ctx, teamName, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range absences {
+			if a.ActiveAt(now) {
+				absent[a.UserId] = true
+			}
+		}
+		for _, m := range members {
+			explain(m.ID, teamName, m.IsActive)
+		}
+	}
+
+	for _, c := range preview.Candidates {
+		explain(c.Id, c.TeamName, true)
+	}
+
+	return excluded, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/repositories"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+type ReviewerRuleSer interface {
+	Save(ctx context.Context, rule domain.ReviewerRule) (domain.ReviewerRule, error)
+	Delete(ctx context.Context, rule domain.ReviewerRule) error
+	GetByUserId(ctx context.Context, userId string) ([]domain.ReviewerRule, error)
+}
+
+type ReviewerRuleService struct {
+	ruleRepo repositories.ReviewerRuleRepo
+	userRepo repositories.UserRepo
+	tm       transaction.Runner
+}
+
+func NewReviewerRuleService(ruleRepo repositories.ReviewerRuleRepo, userRepo repositories.UserRepo, tm transaction.Runner) ReviewerRuleService {
+	return ReviewerRuleService{ruleRepo: ruleRepo, userRepo: userRepo, tm: tm}
+}
+
+func (s *ReviewerRuleService) Save(ctx context.Context, rule domain.ReviewerRule) (domain.ReviewerRule, error) {
+	var saved domain.ReviewerRule
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		if rule.ReviewerId == rule.AuthorId {
+			return validateError.InvalidReviewerRule
+		}
+
+		for _, id := range []string{rule.ReviewerId, rule.AuthorId} {
+			if _, err := s.userRepo.GetById(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		saved, err = s.ruleRepo.Upsert(ctx, rule)
+		return err
+	})
+
+	if err != nil {
+		return domain.ReviewerRule{}, err
+	}
+
+	return saved, nil
+}
+
+func (s *ReviewerRuleService) Delete(ctx context.Context, rule domain.ReviewerRule) error {
+	return s.tm.Do(ctx, func(ctx context.Context) error {
+		return s.ruleRepo.Delete(ctx, rule.ReviewerId, rule.AuthorId)
+	})
+}
+
+func (s *ReviewerRuleService) GetByUserId(ctx context.Context, userId string) ([]domain.ReviewerRule, error) {
+	if _, err := s.userRepo.GetById(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	return s.ruleRepo.GetByUserId(ctx, userId)
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/repositories"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyRoundRobin  = "round_robin"
+	StrategyKnowledge   = "knowledge_spreading"
+)
+
+// ReviewerSelector выбирает ревьюеров из заранее отфильтрованного пула кандидатов.
+type ReviewerSelector interface {
+	Select(ctx context.Context, req SelectRequest) ([]string, error)
+}
+
+type SelectRequest struct {
+	TeamName   string
+	AuthorId   string
+	Candidates []domain.ReviewerCandidate
+	Count      int
+}
+
+// SelectorRegistry хранит стратегии по имени и знает, какая стратегия закреплена за командой.
+type SelectorRegistry struct {
+	selectors       map[string]ReviewerSelector
+	defaultStrategy string
+	teamStrategies  map[string]string
+}
+
+func NewSelectorRegistry(defaultStrategy string, teamStrategies map[string]string) *SelectorRegistry {
+	if teamStrategies == nil {
+		teamStrategies = make(map[string]string)
+	}
+	return &SelectorRegistry{
+		selectors:       make(map[string]ReviewerSelector),
+		defaultStrategy: defaultStrategy,
+		teamStrategies:  teamStrategies,
+	}
+}
+
+func (r *SelectorRegistry) Register(name string, selector ReviewerSelector) {
+	r.selectors[name] = selector
+}
+
+// Validate проверяет, что все стратегии из конфига зарегистрированы.
+func (r *SelectorRegistry) Validate() error {
+	if _, ok := r.selectors[r.defaultStrategy]; !ok {
+		return fmt.Errorf("%w: %s", validateError.UnknownStrategy, r.defaultStrategy)
+	}
+	for team, name := range r.teamStrategies {
+		if _, ok := r.selectors[name]; !ok {
+			return fmt.Errorf("%w: %s (team %s)", validateError.UnknownStrategy, name, team)
+		}
+	}
+	return nil
+}
+
+func (r *SelectorRegistry) ForTeam(teamName string) (ReviewerSelector, error) {
+	name, ok := r.teamStrategies[teamName]
+	if !ok {
+		name = r.defaultStrategy
+	}
+
+	selector, ok := r.selectors[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", validateError.UnknownStrategy, name)
+	}
+	return selector, nil
+}
+
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) Select(_ context.Context, req SelectRequest) ([]string, error) {
+	ids := candidateIds(req.Candidates)
+	rand.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+	return takeFirst(ids, req.Count), nil
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим числом открытых ревью, при равенстве — случайно.
+type LeastLoadedSelector struct{}
+
+func NewLeastLoadedSelector() *LeastLoadedSelector {
+	return &LeastLoadedSelector{}
+}
+
+func (s *LeastLoadedSelector) Select(_ context.Context, req SelectRequest) ([]string, error) {
+	candidates := slices.Clone(req.Candidates)
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	slices.SortStableFunc(candidates, func(a, b domain.ReviewerCandidate) int {
+		return a.OpenReviews - b.OpenReviews
+	})
+	return takeFirst(candidateIds(candidates), req.Count), nil
+}
+
+// RoundRobinSelector раздаёт ревью по сохранённому порядку ротации команды.
+// Курсор двигается под блокировкой строки команды, поэтому должен вызываться внутри транзакции.
+type RoundRobinSelector struct {
+	teamRepo repositories.TeamRepo
+}
+
+func NewRoundRobinSelector(teamRepo repositories.TeamRepo) *RoundRobinSelector {
+	return &RoundRobinSelector{teamRepo: teamRepo}
+}
+
+func (s *RoundRobinSelector) Select(ctx context.Context, req SelectRequest) ([]string, error) {
+	if req.Count <= 0 || len(req.Candidates) == 0 {
+		return nil, nil
+	}
+
+	entries, cursor, err := s.teamRepo.LockRotation(ctx, req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible := make(map[string]bool, len(req.Candidates))
+	for _, c := range req.Candidates {
+		eligible[c.Id] = true
+	}
+
+	// Сначала идут места после курсора, затем очередь начинается сначала
+	split := len(entries)
+	for i, e := range entries {
+		if e.Position > cursor {
+			split = i
+			break
+		}
+	}
+	ordered := append(slices.Clone(entries[split:]), entries[:split]...)
+
+	ids := make([]string, 0, req.Count)
+	for _, e := range ordered {
+		if len(ids) == req.Count {
+			break
+		}
+		if !eligible[e.UserId] {
+			continue
+		}
+		ids = append(ids, e.UserId)
+		delete(eligible, e.UserId)
+		cursor = e.Position
+	}
+
+	if len(ids) > 0 {
+		if err := s.teamRepo.SetRotationCursor(ctx, req.TeamName, cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Кандидаты вне ротации команды добираются в конце в стабильном порядке
+	for _, c := range req.Candidates {
+		if len(ids) == req.Count {
+			break
+		}
+		if eligible[c.Id] {
+			ids = append(ids, c.Id)
+			delete(eligible, c.Id)
+		}
+	}
+
+	return ids, nil
+}
+
+// KnowledgeSpreadingSelector отдаёт ревью тем, кто реже смотрел PR автора за последнее окно history,
+// чтобы знание о коде расходилось по команде. При равенстве выбирает случайно.
+type KnowledgeSpreadingSelector struct {
+	prRepo  repositories.PrRepo
+	clock   Clock
+	history time.Duration
+}
+
+func NewKnowledgeSpreadingSelector(prRepo repositories.PrRepo, clock Clock, history time.Duration) *KnowledgeSpreadingSelector {
+	return &KnowledgeSpreadingSelector{prRepo: prRepo, clock: clock, history: history}
+}
+
+func (s *KnowledgeSpreadingSelector) Select(ctx context.Context, req SelectRequest) ([]string, error) {
+	if req.Count <= 0 || len(req.Candidates) == 0 {
+		return nil, nil
+	}
+
+	recent, err := s.prRepo.GetRecentReviewCounts(ctx, req.AuthorId, s.clock.Now().Add(-s.history))
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := slices.Clone(req.Candidates)
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	slices.SortStableFunc(candidates, func(a, b domain.ReviewerCandidate) int {
+		return recent[a.Id] - recent[b.Id]
+	})
+	return takeFirst(candidateIds(candidates), req.Count), nil
+}
+
+func candidateIds(candidates []domain.ReviewerCandidate) []string {
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.Id)
+	}
+	return ids
+}
+
+func takeFirst(ids []string, count int) []string {
+	if count < len(ids) {
+		return ids[:count]
+	}
+	return ids
+}
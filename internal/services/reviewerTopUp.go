@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// ReviewerTopUp добирает ревьюеров в открытые PR команды, у которых остались свободные места.
+// Вызывается, когда в команде появляются доступные люди: активация, вступление, возвращение из отсутствия.
+type ReviewerTopUp interface {
+	TopUpTeam(ctx context.Context, teamName string) ([]domain.TopUp, error)
+}
+
+func (s *PRService) TopUpTeam(ctx context.Context, teamName string) ([]domain.TopUp, error) {
+	topUps := make([]domain.TopUp, 0)
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		team, err := s.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		prs, err := s.prRepo.GetUnderstaffedByTeamName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		for _, pr := range prs {
+			added, err := s.fillSlots(ctx, team, pr)
+			if err != nil {
+				return err
+			}
+			if len(added) > 0 {
+				topUps = append(topUps, domain.TopUp{PullRequestId: pr.Id, Added: added})
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return topUps, nil
+}
+
+// TopUpAll проходит по всем командам с недоукомплектованными PR; нужен для тех, кто вернулся из отсутствия сам по себе.
+func (s *PRService) TopUpAll(ctx context.Context) ([]domain.TopUp, error) {
+	teamNames, err := s.prRepo.GetUnderstaffedTeamNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	topUps := make([]domain.TopUp, 0)
+	for _, teamName := range teamNames {
+		teamTopUps, err := s.TopUpTeam(ctx, teamName)
+		if err != nil {
+			return topUps, err
+		}
+		topUps = append(topUps, teamTopUps...)
+	}
+	return topUps, nil
+}
+
+// fillSlots заполняет свободные места PR по тем же правилам, что и при создании, кроме владельцев кода:
+// список изменённых файлов не сохраняется.
+func (s *PRService) fillSlots(ctx context.Context, team domain.Team, pr domain.PullRequestRead) ([]domain.AssignedReviewer, error) {
+	author, err := s.userRepo.GetById(ctx, pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.prRepo.GetReviewersById(ctx, pr.Id)
+	if err != nil {
+		return nil, err
+	}
+	free := team.ReviewersCount - len(current)
+	if free <= 0 {
+		return nil, nil
+	}
+
+	declined, err := s.declineRepo.GetReviewerIdsByPR(ctx, pr.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	pools, err := s.loadPools(ctx, author, team, nil, append(domain.ReviewerIds(current), declined...))
+	if err != nil {
+		return nil, err
+	}
+
+	picked, err := s.pickReviewers(ctx, author, team, pools, free)
+	if errors.Is(err, validateError.ReviewersAtCapacity) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seniorPresent, err := s.hasSenior(ctx, team, domain.ReviewerIds(current), "")
+	if err != nil {
+		return nil, err
+	}
+	if !seniorPresent {
+		picked, err = s.ensureSenior(ctx, author, team, pools, picked, free)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(picked) == 0 {
+		return nil, nil
+	}
+
+	assigned, err := s.prRepo.AssignReviewers(ctx, pr.Id, picked)
+	if err != nil {
+		return nil, err
+	}
+	return assigned, s.recordAssigned(ctx, pr.Id, assigned)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// PushRevision записывает новую ревизию открытого PR. Решения, принятые на прежних ревизиях,
+// устаревают по политике команды автора и перестают учитываться при слиянии.
+func (s *PRService) PushRevision(ctx context.Context, revision domain.Revision) (domain.RevisionPush, error) {
+	var push domain.RevisionPush
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		current, err := s.prRepo.GetById(ctx, revision.PullRequestId)
+		if err != nil {
+			return err
+		}
+		if err := requireOpen(current); err != nil {
+			return err
+		}
+
+		revisions, err := s.revisionRepo.GetByPullRequestId(ctx, revision.PullRequestId)
+		if err != nil {
+			return err
+		}
+		for _, r := range revisions {
+			if r.CommitSHA == revision.CommitSHA {
+				return validateError.RevisionExists
+			}
+		}
+
+		if revision.PushedAt.IsZero() {
+			revision.PushedAt = s.clock.Now()
+		}
+		push.Revision, err = s.revisionRepo.Create(ctx, revision)
+		if err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, domain.PREvent{PullRequestId: current.Id, Kind: domain.EventRevisionPushed, Details: push.Revision.CommitSHA}); err != nil {
+			return err
+		}
+
+		author, err := s.userRepo.GetById(ctx, current.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		push.Stale = []domain.ReviewDecision{}
+		if kinds := team.StaleReviewPolicy.DismissedKinds(); len(kinds) > 0 {
+			push.Stale, err = s.prRepo.MarkDecisionsStale(ctx, current.Id, push.Revision.Id, kinds)
+			if err != nil {
+				return err
+			}
+		}
+
+		push.PullRequest, err = s.withReviewers(ctx, current)
+		return err
+	})
+
+	if err != nil {
+		return domain.RevisionPush{}, err
+	}
+	return push, nil
+}
+
+func (s *PRService) GetRevisions(ctx context.Context, prId string) ([]domain.Revision, error) {
+	if _, err := s.prRepo.GetById(ctx, prId); err != nil {
+		return nil, err
+	}
+
+	return s.revisionRepo.GetByPullRequestId(ctx, prId)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/linspacestrom/InterShipAv/internal/domain"
 	"github.com/linspacestrom/InterShipAv/internal/repositories"
@@ -11,24 +12,42 @@ import (
 )
 
 type TeamSer interface {
-	Create(ctx context.Context, team domain.Team) (domain.Team, error)
+	Create(ctx context.Context, team domain.Team) (domain.Team, []domain.TopUp, error)
 	GetByName(ctx context.Context, name string) (domain.Team, error)
+	UploadCodeOwners(ctx context.Context, teamName string, content string) ([]domain.CodeOwnerRule, error)
+	GetCodeOwners(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error)
 }
 
 type TeamService struct {
-	teamRepo repositories.TeamRepo
-	userRepo repositories.UserRepo
-	tm       *transaction.Manager
+	teamRepo      repositories.TeamRepo
+	userRepo      repositories.UserRepo
+	codeOwnerRepo repositories.CodeOwnerRepo
+	absenceRepo   repositories.AbsenceRepo
+	topUp         ReviewerTopUp
+	clock         Clock
+	tm            transaction.Runner
 }
 
-func NewTeamService(teamRepo repositories.TeamRepo, userRepo repositories.UserRepo, tm *transaction.Manager) TeamService {
-	return TeamService{teamRepo: teamRepo, userRepo: userRepo, tm: tm}
+func NewTeamService(teamRepo repositories.TeamRepo, userRepo repositories.UserRepo, codeOwnerRepo repositories.CodeOwnerRepo, absenceRepo repositories.AbsenceRepo, topUp ReviewerTopUp, clock Clock, tm transaction.Runner) TeamService {
+	return TeamService{teamRepo: teamRepo, userRepo: userRepo, codeOwnerRepo: codeOwnerRepo, absenceRepo: absenceRepo, topUp: topUp, clock: clock, tm: tm}
 }
 
-func (s *TeamService) Create(ctx context.Context, team domain.Team) (domain.Team, error) {
+// Create также добирает ревьюеров в открытые PR участников, перешедших в новую команду.
+// Добор выполняется после фиксации команды: его сбой не отменяет создание и возвращается как TopUpFailed.
+func (s *TeamService) Create(ctx context.Context, team domain.Team) (domain.Team, []domain.TopUp, error) {
 	var createdTeam domain.Team
 
 	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		if team.ReviewersCount < 1 || team.MinReviewers < 0 || team.MinReviewers > team.ReviewersCount {
+			return validateError.InvalidReviewersCount
+		}
+		if team.CapacityPolicy == "" {
+			team.CapacityPolicy = domain.CapacityOverAssign
+		}
+		if team.StaleReviewPolicy == "" {
+			team.StaleReviewPolicy = domain.StaleKeep
+		}
+
 		uniqueUserMap := make(map[string]bool, len(team.Members)+1)
 
 		for _, member := range team.Members {
@@ -46,6 +65,10 @@ func (s *TeamService) Create(ctx context.Context, team domain.Team) (domain.Team
 			return err
 		}
 
+		if err := s.validateFallbackTeams(ctx, team); err != nil {
+			return err
+		}
+
 		createdTeam, err = s.teamRepo.Create(ctx, team)
 		if err != nil {
 			return err
@@ -66,10 +89,15 @@ func (s *TeamService) Create(ctx context.Context, team domain.Team) (domain.Team
 	})
 
 	if err != nil {
-		return domain.Team{}, err
+		return domain.Team{}, nil, err
+	}
+
+	topUps, err := s.topUp.TopUpTeam(ctx, createdTeam.Name)
+	if err != nil {
+		return createdTeam, nil, fmt.Errorf("%w: %w", validateError.TopUpFailed, err)
 	}
 
-	return createdTeam, nil
+	return createdTeam, topUps, nil
 }
 
 func (s *TeamService) GetByName(ctx context.Context, name string) (domain.Team, error) {
@@ -85,6 +113,18 @@ func (s *TeamService) GetByName(ctx context.Context, name string) (domain.Team,
 		if err != nil {
 			return nil
 		}
+
+		absences, err := s.absenceRepo.GetActualByTeamName(ctx, team.Name, s.clock.Now())
+		if err != nil {
+			return err
+		}
+		byUser := make(map[string][]domain.Absence, len(absences))
+		for _, a := range absences {
+			byUser[a.UserId] = append(byUser[a.UserId], a)
+		}
+		for i := range users {
+			users[i].Absences = byUser[users[i].ID]
+		}
 		team.Members = users
 
 		return nil
@@ -96,3 +136,50 @@ func (s *TeamService) GetByName(ctx context.Context, name string) (domain.Team,
 
 	return team, err
 }
+
+func (s *TeamService) validateFallbackTeams(ctx context.Context, team domain.Team) error {
+	seen := make(map[string]bool, len(team.FallbackTeams))
+	for _, fallback := range team.FallbackTeams {
+		if fallback == team.Name || seen[fallback] {
+			return validateError.InvalidFallbackTeam
+		}
+		seen[fallback] = true
+
+		if _, err := s.teamRepo.GetByName(ctx, fallback); err != nil {
+			if errors.Is(err, validateError.TeamNotFound) {
+				return validateError.InvalidFallbackTeam
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TeamService) UploadCodeOwners(ctx context.Context, teamName string, content string) ([]domain.CodeOwnerRule, error) {
+	rules, err := ParseCodeOwners(content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tm.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+			return err
+		}
+
+		return s.codeOwnerRepo.ReplaceRules(ctx, teamName, rules)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *TeamService) GetCodeOwners(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	return s.codeOwnerRepo.GetRulesByTeamName(ctx, teamName)
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/linspacestrom/InterShipAv/internal/domain"
 	"github.com/linspacestrom/InterShipAv/internal/repositories"
@@ -10,21 +12,26 @@ import (
 )
 
 type UserSer interface {
-	SetActive(ctx context.Context, id string, isActive bool) (domain.User, error)
+	SetActive(ctx context.Context, id string, isActive bool) (domain.User, []domain.TopUp, error)
+	SetMaxOpenReviews(ctx context.Context, capacity domain.UserCapacity) (domain.User, error)
+	SetSchedule(ctx context.Context, id string, schedule domain.WorkSchedule) (domain.User, error)
 	GetReview(ctx context.Context, userId string) (domain.UserReview, error)
 }
 
 type UserService struct {
 	userRepo repositories.UserRepo
 	prRepo   repositories.PrRepo
-	tm       *transaction.Manager
+	topUp    ReviewerTopUp
+	tm       transaction.Runner
 }
 
-func NewUserService(userRepo repositories.UserRepo, prRepo repositories.PrRepo, tm *transaction.Manager) UserService {
-	return UserService{userRepo: userRepo, prRepo: prRepo, tm: tm}
+func NewUserService(userRepo repositories.UserRepo, prRepo repositories.PrRepo, topUp ReviewerTopUp, tm transaction.Runner) UserService {
+	return UserService{userRepo: userRepo, prRepo: prRepo, topUp: topUp, tm: tm}
 }
 
-func (s *UserService) SetActive(ctx context.Context, id string, isActive bool) (domain.User, error) {
+// SetActive при активации пользователя добирает ревьюеров в недоукомплектованные PR его команды.
+// Добор выполняется после фиксации активации: его сбой не отменяет её и возвращается как TopUpFailed.
+func (s *UserService) SetActive(ctx context.Context, id string, isActive bool) (domain.User, []domain.TopUp, error) {
 	var user domain.User
 	err := s.tm.Do(ctx, func(ctx context.Context) error {
 		_, err := s.userRepo.GetById(ctx, id)
@@ -33,12 +40,59 @@ func (s *UserService) SetActive(ctx context.Context, id string, isActive bool) (
 		}
 
 		user, err = s.userRepo.SetActiveById(ctx, id, isActive)
+		return err
+	})
 
-		if err != nil {
-			return err
-		}
+	if err != nil {
+		return user, nil, err
+	}
+	if !isActive {
+		return user, nil, nil
+	}
 
-		return nil
+	topUps, err := s.topUp.TopUpTeam(ctx, user.TeamName)
+	if err != nil {
+		return user, nil, fmt.Errorf("%w: %w", validateError.TopUpFailed, err)
+	}
+
+	return user, topUps, nil
+}
+
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, capacity domain.UserCapacity) (domain.User, error) {
+	var user domain.User
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.SetMaxOpenReviewsById(ctx, capacity.Id, capacity.MaxOpenReviews)
+		return err
+	})
+
+	if err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) SetSchedule(ctx context.Context, id string, schedule domain.WorkSchedule) (domain.User, error) {
+	var user domain.User
+
+	if schedule.TimeZone == "" {
+		schedule.TimeZone = domain.DefaultTimeZone
+	}
+	if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		return user, validateError.InvalidSchedule
+	}
+	if (schedule.WorkStart == nil) != (schedule.WorkEnd == nil) {
+		return user, validateError.InvalidSchedule
+	}
+	if schedule.HasHours() && *schedule.WorkStart == *schedule.WorkEnd {
+		return user, validateError.InvalidSchedule
+	}
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.SetScheduleById(ctx, id, schedule)
+		return err
 	})
 
 	if err != nil {
@@ -66,6 +120,18 @@ func (s *UserService) GetReview(ctx context.Context, userId string) (domain.User
 		return reviewer, nil
 	}
 
+	decisions, err := s.prRepo.GetLatestDecisionsByReviewerId(ctx, userId)
+	if err != nil {
+		return reviewer, nil
+	}
+	for i := range prReviews {
+		if d, ok := decisions[prReviews[i].Id]; ok {
+			prReviews[i].Decision = &d
+		}
+	}
+
+	domain.SortReviewQueue(prReviews)
+
 	reviewer.Id = user.Id
 	reviewer.PullRequests = prReviews
 
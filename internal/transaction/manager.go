@@ -8,6 +8,12 @@ import (
 
 type txKey struct{}
 
+// Runner выполняет fn в одной транзакции. Сервисы зависят от него, а не от Manager,
+// чтобы их можно было проверять без базы.
+type Runner interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Manager struct {
 	pool *pgxpool.Pool
 }
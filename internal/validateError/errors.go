@@ -2,6 +2,8 @@ package validateError
 
 import (
 	"errors"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
 )
 
 var ErrTeamExists = errors.New("team already exist")
@@ -14,3 +16,45 @@ var UserNotAssignReviewer = errors.New("user not assign to pull request")
 var NoCandidate = errors.New("no active replacement candidate in team")
 var UserNotAssignToTeam = errors.New("user not assign to team")
 var UserNotUniqueId = errors.New("users hasn`t unique ids")
+var InvalidReviewersCount = errors.New("min reviewers must be between 0 and reviewers count")
+var NotEnoughReviewers = errors.New("not enough reviewers available to meet team minimum")
+var ReviewersAtCapacity = errors.New("all reviewer candidates are at capacity")
+var InvalidFallbackTeam = errors.New("fallback team must exist and differ from the team itself")
+var InvalidCodeOwners = errors.New("invalid code owners file")
+var InvalidReviewerRule = errors.New("reviewer and author must be different users")
+var ReviewerRuleNotFound = errors.New("reviewer rule not found")
+var NoSeniorCandidate = errors.New("no replacement candidate with required level for the only senior reviewer")
+var InvalidAbsence = errors.New("absence must end after it starts")
+var AbsenceNotFound = errors.New("absence not found")
+var InvalidSchedule = errors.New("invalid time zone or working hours")
+var ReviewerIsAuthor = errors.New("author cannot review own pull request")
+var ReviewerInactive = errors.New("reviewer is not active")
+var ReviewerExcluded = errors.New("reviewer is excluded from reviewing this author")
+var ReviewerAbsent = errors.New("reviewer is absent")
+var ReviewerAlreadyAssigned = errors.New("user already assigned as reviewer")
+var ReviewerDeclined = errors.New("reviewer declined this pull request")
+var SeniorReviewerRequired = errors.New("the only senior reviewer can be replaced only by a senior")
+var MergeBlocked = errors.New("merge policy conditions not met")
+var ForceMergeNotAllowed = errors.New("only admins can force merge")
+var InvalidStatusTransition = errors.New("pull request status does not allow this transition")
+var PrNotOpen = errors.New("pull request is not open")
+var DraftWithReviewers = errors.New("draft pull request cannot have reviewers")
+var AuthorInactive = errors.New("new author is not active")
+var InvalidParent = errors.New("parent pull request must exist and differ from the pull request itself")
+var DependencyCycle = errors.New("pull request dependencies must not form a cycle")
+var RevisionExists = errors.New("revision with this commit already recorded")
+var TopUpFailed = errors.New("reviewer top-up failed")
+var UnknownStrategy = errors.New("unknown reviewer selection strategy")
+
+// MergeBlockedError перечисляет невыполненные условия политики слияния и сравнивается с MergeBlocked.
+type MergeBlockedError struct {
+	Unmet []domain.MergeCondition
+}
+
+func (e *MergeBlockedError) Error() string {
+	return MergeBlocked.Error()
+}
+
+func (e *MergeBlockedError) Unwrap() error {
+	return MergeBlocked
+}
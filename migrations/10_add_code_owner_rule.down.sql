@@ -0,0 +1,2 @@
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS matched_rule;
+DROP TABLE IF EXISTS code_owner_rule;
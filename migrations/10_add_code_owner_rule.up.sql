@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS code_owner_rule (
+    team_name TEXT   NOT NULL REFERENCES team(team_name) ON DELETE CASCADE,
+    position  INT    NOT NULL,
+    pattern   TEXT   NOT NULL,
+    owners    TEXT[] NOT NULL,
+
+    PRIMARY KEY (team_name, position)
+);
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS matched_rule TEXT DEFAULT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reviewer_rule;
\ No newline at end of file
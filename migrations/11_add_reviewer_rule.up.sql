@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS reviewer_rule (
+    reviewer_id TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    author_id   TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    kind        TEXT NOT NULL CHECK (kind IN ('exclude', 'prefer')),
+
+    PRIMARY KEY (reviewer_id, author_id),
+    CHECK (reviewer_id != author_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_rule_author_id ON reviewer_rule (author_id);
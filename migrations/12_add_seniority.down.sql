@@ -0,0 +1,2 @@
+ALTER TABLE team DROP COLUMN IF EXISTS senior_level;
+ALTER TABLE "user" DROP COLUMN IF EXISTS level;
@@ -0,0 +1,5 @@
+ALTER TABLE "user"
+    ADD COLUMN IF NOT EXISTS level INT NOT NULL DEFAULT 1 CHECK (level >= 1);
+
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS senior_level INT DEFAULT NULL CHECK (senior_level >= 1);
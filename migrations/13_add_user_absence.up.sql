@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_absence (
+    absence_id BIGSERIAL PRIMARY KEY,
+    user_id    TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ NOT NULL,
+    reason     TEXT NOT NULL DEFAULT '',
+
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absence_user_id_ends_at ON user_absence (user_id, ends_at);
@@ -0,0 +1,5 @@
+ALTER TABLE "user"
+    DROP CONSTRAINT IF EXISTS user_work_hours_pair,
+    DROP COLUMN IF EXISTS work_end,
+    DROP COLUMN IF EXISTS work_start,
+    DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,5 @@
+ALTER TABLE "user"
+    ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC',
+    ADD COLUMN IF NOT EXISTS work_start INT DEFAULT NULL CHECK (work_start BETWEEN 0 AND 1439),
+    ADD COLUMN IF NOT EXISTS work_end INT DEFAULT NULL CHECK (work_end BETWEEN 0 AND 1439),
+    ADD CONSTRAINT user_work_hours_pair CHECK ((work_start IS NULL) = (work_end IS NULL));
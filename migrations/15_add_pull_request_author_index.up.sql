@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_pull_request_author_id_created_at ON pull_request (author_id, created_at);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS review_decline (
+    pull_request_id TEXT NOT NULL REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+    reviewer_id     TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    reason          TEXT NOT NULL CHECK (reason IN ('conflict', 'no_context', 'overloaded')),
+    comment         TEXT NOT NULL DEFAULT '',
+    declined_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_decline_reviewer_id ON review_decline (reviewer_id);
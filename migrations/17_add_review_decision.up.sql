@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS review_decision (
+    decision_id     BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+    reviewer_id     TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    decision        TEXT NOT NULL CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    body            TEXT NOT NULL DEFAULT '',
+    submitted_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_decision_pr_reviewer ON review_decision (pull_request_id, reviewer_id, decision_id DESC);
+CREATE INDEX IF NOT EXISTS idx_review_decision_reviewer_id ON review_decision (reviewer_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS merge_override;
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS is_admin;
+
+ALTER TABLE team DROP COLUMN IF EXISTS required_approvals;
@@ -0,0 +1,12 @@
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS required_approvals INT NOT NULL DEFAULT 0 CHECK (required_approvals >= 0);
+
+ALTER TABLE "user"
+    ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS merge_override (
+    pull_request_id TEXT PRIMARY KEY REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+    admin_id        TEXT NOT NULL REFERENCES "user"(id),
+    unmet           TEXT[] NOT NULL DEFAULT '{}',
+    forced_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
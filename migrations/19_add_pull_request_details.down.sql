@@ -0,0 +1,3 @@
+ALTER TABLE pull_request
+    DROP COLUMN IF EXISTS external_url,
+    DROP COLUMN IF EXISTS description;
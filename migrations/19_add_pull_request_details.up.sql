@@ -0,0 +1,3 @@
+ALTER TABLE pull_request
+    ADD COLUMN IF NOT EXISTS description  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS external_url TEXT NOT NULL DEFAULT '';
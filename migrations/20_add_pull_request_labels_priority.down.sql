@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pull_request_labels;
+DROP INDEX IF EXISTS idx_pull_request_priority_created_at;
+
+ALTER TABLE pull_request
+    DROP COLUMN IF EXISTS labels,
+    DROP COLUMN IF EXISTS priority;
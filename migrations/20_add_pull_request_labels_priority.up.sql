@@ -0,0 +1,6 @@
+ALTER TABLE pull_request
+    ADD COLUMN IF NOT EXISTS priority TEXT NOT NULL DEFAULT 'normal' CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+    ADD COLUMN IF NOT EXISTS labels   TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_priority_created_at ON pull_request (priority, created_at);
+CREATE INDEX IF NOT EXISTS idx_pull_request_labels ON pull_request USING GIN (labels);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS pull_request_dependency (
+    pull_request_id TEXT NOT NULL REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+    parent_id       TEXT NOT NULL REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+
+    PRIMARY KEY (pull_request_id, parent_id),
+    CHECK (pull_request_id != parent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_dependency_parent_id ON pull_request_dependency (parent_id);
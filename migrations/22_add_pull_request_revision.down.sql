@@ -0,0 +1,7 @@
+ALTER TABLE team DROP COLUMN IF EXISTS stale_review_policy;
+
+ALTER TABLE review_decision
+    DROP COLUMN IF EXISTS stale,
+    DROP COLUMN IF EXISTS revision_id;
+
+DROP TABLE IF EXISTS pull_request_revision;
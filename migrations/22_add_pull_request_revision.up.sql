@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS pull_request_revision (
+    revision_id     BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+    commit_sha      TEXT NOT NULL,
+    pushed_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (pull_request_id, commit_sha)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_revision_pr ON pull_request_revision (pull_request_id, revision_id DESC);
+
+ALTER TABLE review_decision
+    ADD COLUMN IF NOT EXISTS revision_id BIGINT REFERENCES pull_request_revision(revision_id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS stale       BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS stale_review_policy TEXT NOT NULL DEFAULT 'keep'
+        CHECK (stale_review_policy IN ('dismiss_all', 'dismiss_approvals', 'keep'));
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS pr_events (
+    event_id        BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_request(pull_request_id) ON DELETE CASCADE,
+    kind            TEXT NOT NULL,
+    actor_id        TEXT DEFAULT NULL,
+    from_user_id    TEXT DEFAULT NULL,
+    to_user_id      TEXT DEFAULT NULL,
+    details         TEXT DEFAULT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr ON pr_events (pull_request_id, event_id);
+
+INSERT INTO pr_events (pull_request_id, kind, actor_id, created_at)
+SELECT pull_request_id, 'created', author_id, COALESCE(created_at, NOW())
+FROM pull_request;
+
+INSERT INTO pr_events (pull_request_id, kind, created_at)
+SELECT pull_request_id, 'merged', merged_at
+FROM pull_request
+WHERE status = 'MERGED' AND merged_at IS NOT NULL;
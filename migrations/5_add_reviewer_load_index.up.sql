@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer_id ON pr_reviewers (reviewer_id);
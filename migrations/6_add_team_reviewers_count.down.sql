@@ -0,0 +1,4 @@
+ALTER TABLE team
+    DROP CONSTRAINT IF EXISTS team_min_reviewers_le_count,
+    DROP COLUMN IF EXISTS min_reviewers,
+    DROP COLUMN IF EXISTS reviewers_count;
@@ -0,0 +1,6 @@
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS reviewers_count INT NOT NULL DEFAULT 2 CHECK (reviewers_count >= 1),
+    ADD COLUMN IF NOT EXISTS min_reviewers   INT NOT NULL DEFAULT 0 CHECK (min_reviewers >= 0);
+
+ALTER TABLE team
+    ADD CONSTRAINT team_min_reviewers_le_count CHECK (min_reviewers <= reviewers_count);
@@ -0,0 +1,2 @@
+ALTER TABLE team DROP COLUMN IF EXISTS capacity_policy;
+ALTER TABLE "user" DROP COLUMN IF EXISTS max_open_reviews;
@@ -0,0 +1,6 @@
+ALTER TABLE "user"
+    ADD COLUMN IF NOT EXISTS max_open_reviews INT DEFAULT NULL CHECK (max_open_reviews >= 0);
+
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS capacity_policy TEXT NOT NULL DEFAULT 'over_assign'
+        CHECK (capacity_policy IN ('over_assign', 'leave_empty', 'reject'));
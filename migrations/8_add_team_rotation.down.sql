@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS team_rotation;
+ALTER TABLE team DROP COLUMN IF EXISTS rotation_cursor;
@@ -0,0 +1,15 @@
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS rotation_cursor INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS team_rotation (
+    user_id   TEXT PRIMARY KEY REFERENCES "user"(id) ON DELETE CASCADE,
+    team_name TEXT NOT NULL REFERENCES team(team_name) ON DELETE CASCADE,
+    position  INT  NOT NULL,
+
+    UNIQUE (team_name, position)
+);
+
+INSERT INTO team_rotation (team_name, user_id, position)
+SELECT team_name, id, ROW_NUMBER() OVER (PARTITION BY team_name ORDER BY id)
+FROM "user"
+ON CONFLICT (user_id) DO NOTHING;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS team_fallback (
+    team_name          TEXT NOT NULL REFERENCES team(team_name) ON DELETE CASCADE,
+    fallback_team_name TEXT NOT NULL REFERENCES team(team_name) ON DELETE CASCADE,
+    priority           INT  NOT NULL,
+
+    PRIMARY KEY (team_name, fallback_team_name),
+    CHECK (team_name != fallback_team_name)
+);
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS is_external BOOLEAN NOT NULL DEFAULT FALSE;
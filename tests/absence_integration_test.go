@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsenceHandler_AddAbsence(t *testing.T) {
+	t.Run("successfully adds absence", func(t *testing.T) {
+		absenceSvc := NewFakeAbsenceService()
+		router := SetupAbsenceRouter(absenceSvc)
+
+		payload := map[string]interface{}{
+			"user_id":   testUserID1,
+			"starts_at": "2026-07-01T00:00:00Z",
+			"ends_at":   "2026-07-15T00:00:00Z",
+			"reason":    "vacation",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/absences/add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"absence_id":1`)
+
+		getReq := httptest.NewRequest(http.MethodGet, "/absences/get/"+testUserID1, nil)
+		getW := httptest.NewRecorder()
+		router.ServeHTTP(getW, getReq)
+
+		assert.Equal(t, http.StatusOK, getW.Code)
+		assert.Contains(t, getW.Body.String(), `"reason":"vacation"`)
+	})
+
+	t.Run("rejects absence ending before start", func(t *testing.T) {
+		absenceSvc := NewFakeAbsenceService()
+		router := SetupAbsenceRouter(absenceSvc)
+
+		payload := map[string]interface{}{
+			"user_id":   testUserID1,
+			"starts_at": "2026-07-15T00:00:00Z",
+			"ends_at":   "2026-07-01T00:00:00Z",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/absences/add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAbsenceHandler_DeleteAbsence(t *testing.T) {
+	t.Run("returns not found for unknown absence", func(t *testing.T) {
+		absenceSvc := NewFakeAbsenceService()
+		router := SetupAbsenceRouter(absenceSvc)
+
+		body, err := json.Marshal(map[string]interface{}{"absence_id": 42})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/absences/delete", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("keeps deletion when top-up fails", func(t *testing.T) {
+		absenceSvc := NewFakeAbsenceService()
+		absenceSvc.absences[1] = domain.Absence{Id: 1, UserId: testUserID1}
+		absenceSvc.topUpErr = validateError.ReviewersAtCapacity
+		router := SetupAbsenceRouter(absenceSvc)
+
+		body, err := json.Marshal(map[string]interface{}{"absence_id": 1})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/absences/delete", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"topped_up":[]`)
+		assert.Empty(t, absenceSvc.absences)
+	})
+}
+
+func TestAbsenceService_DeleteTopUp(t *testing.T) {
+	ctx := context.Background()
+	newStore := func() (*MemoryStore, domain.Absence) {
+		store := NewMemoryStore(fixedNow)
+		seedDevTeam(store, domain.Team{})
+		store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusOpen},
+			domain.AssignedReviewer{Id: "u2"})
+
+		absences := store.Repos().Absence
+		vacation := domain.Absence{UserId: "u3", StartsAt: fixedNow.Add(-time.Hour), EndsAt: fixedNow.Add(24 * time.Hour)}
+		absence, _ := absences.Create(ctx, vacation)
+		vacation.UserId = "u4"
+		_, _ = absences.Create(ctx, vacation)
+		return store, absence
+	}
+
+	t.Run("returned user tops up understaffed PRs", func(t *testing.T) {
+		store, absence := newStore()
+		svc := newMemoryAbsenceService(store, newMemoryPRService(store))
+
+		topUps, err := svc.Delete(ctx, absence.Id)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TopUp{{PullRequestId: testPRID, Added: []domain.AssignedReviewer{{Id: "u3"}}}}, topUps)
+	})
+
+	t.Run("absence stays deleted when top-up fails", func(t *testing.T) {
+		store, absence := newStore()
+		topUpErr := errors.New("selector unavailable")
+		svc := newMemoryAbsenceService(store, failingTopUp{err: topUpErr})
+
+		topUps, err := svc.Delete(ctx, absence.Id)
+		assert.ErrorIs(t, err, validateError.TopUpFailed)
+		assert.ErrorIs(t, err, topUpErr)
+		assert.Empty(t, topUps)
+
+		remaining, err := store.Repos().Absence.GetByUserId(ctx, "u3")
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+}
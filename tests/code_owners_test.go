@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/services"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCodeOwners = `
+# backend owners
+*.go            @u2
+/internal/db/   @u3 @team/dba
+docs/**/*.md    @u4
+`
+
+func TestParseCodeOwners(t *testing.T) {
+	t.Run("parses rules and skips comments", func(t *testing.T) {
+		rules, err := services.ParseCodeOwners(testCodeOwners)
+		require.NoError(t, err)
+		require.Len(t, rules, 3)
+		assert.Equal(t, "/internal/db/", rules[1].Pattern)
+		assert.Equal(t, []string{"@u3", "@team/dba"}, rules[1].Owners)
+	})
+
+	t.Run("rejects rule without owners", func(t *testing.T) {
+		_, err := services.ParseCodeOwners("*.go\n")
+		assert.ErrorIs(t, err, validateError.InvalidCodeOwners)
+	})
+
+	t.Run("rejects owner without @", func(t *testing.T) {
+		_, err := services.ParseCodeOwners("*.go u2\n")
+		assert.ErrorIs(t, err, validateError.InvalidCodeOwners)
+	})
+}
+
+func TestMatchCodeOwners(t *testing.T) {
+	rules, err := services.ParseCodeOwners(testCodeOwners)
+	require.NoError(t, err)
+
+	t.Run("last matching rule wins", func(t *testing.T) {
+		matched := services.MatchCodeOwners(rules, []string{"internal/db/db.go"})
+		require.Len(t, matched, 1)
+		assert.Equal(t, "/internal/db/", matched[0].Pattern)
+	})
+
+	t.Run("basename pattern matches at any depth", func(t *testing.T) {
+		matched := services.MatchCodeOwners(rules, []string{"internal/services/team.go", "cmd/server/main.go"})
+		require.Len(t, matched, 1)
+		assert.Equal(t, "*.go", matched[0].Pattern)
+	})
+
+	t.Run("double star spans directories", func(t *testing.T) {
+		matched := services.MatchCodeOwners(rules, []string{"docs/api/v1/readme.md", "README.md"})
+		require.Len(t, matched, 1)
+		assert.Equal(t, "docs/**/*.md", matched[0].Pattern)
+	})
+
+	t.Run("team owner token", func(t *testing.T) {
+		assert.Equal(t, domain.CodeOwner{TeamName: "dba"}, domain.ParseCodeOwner("@team/dba"))
+		assert.Equal(t, domain.CodeOwner{UserId: "u3"}, domain.ParseCodeOwner("@u3"))
+	})
+}
+
+func TestTeamHandler_UploadCodeOwners(t *testing.T) {
+	newUpload := func(t *testing.T, team string, content string) *http.Request {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "CODEOWNERS")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/team/codeOwners/"+team, &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("successfully uploads rules", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		teamSvc.createCalls[testTeamName] = 1
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newUpload(t, testTeamName, testCodeOwners))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"pattern":"*.go"`)
+	})
+
+	t.Run("returns 400 for malformed file", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		teamSvc.createCalls[testTeamName] = 1
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newUpload(t, testTeamName, "*.go\n"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("returns 404 for unknown team", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newUpload(t, "unknown", testCodeOwners))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stackGraph — стек a <- b <- d и a <- c <- d: d зависит от b и c, оба зависят от a.
+func stackGraph(statuses map[string]domain.PullRequestStatus) domain.PRDependencyGraph {
+	graph := domain.PRDependencyGraph{Id: "d", Edges: []domain.PRDependency{
+		{ChildId: "b", ParentId: "a"},
+		{ChildId: "c", ParentId: "a"},
+		{ChildId: "d", ParentId: "b"},
+		{ChildId: "d", ParentId: "c"},
+	}}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		graph.Nodes = append(graph.Nodes, domain.PRGraphNode{Id: id, Status: statuses[id]})
+	}
+	return graph
+}
+
+func TestPRDependencyGraph(t *testing.T) {
+	t.Run("blocking chain lists open ancestors in merge order", func(t *testing.T) {
+		graph := stackGraph(map[string]domain.PullRequestStatus{
+			"a": domain.StatusOpen, "b": domain.StatusDraft, "c": domain.StatusOpen, "d": domain.StatusOpen,
+		})
+		assert.Equal(t, []string{"a", "b", "c"}, graph.BlockingChain("d"))
+		assert.Equal(t, []string{"a", "b", "c"}, graph.Ancestors("d"))
+	})
+
+	t.Run("merged and closed parents do not block", func(t *testing.T) {
+		graph := stackGraph(map[string]domain.PullRequestStatus{
+			"a": domain.StatusOpen, "b": domain.StatusMerged, "c": domain.StatusClosed, "d": domain.StatusOpen,
+		})
+		assert.Empty(t, graph.BlockingChain("d"))
+		assert.Equal(t, []string{"a"}, graph.BlockingChain("b"))
+	})
+}
+
+func TestPRService_StackedPRs(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(fixedNow)
+	seedDevTeam(store, domain.Team{})
+	svc := newMemoryPRService(store)
+
+	create := func(id string, parents ...string) error {
+		_, err := svc.Create(ctx, domain.PullRequestCreate{Id: id, Name: id, AuthorId: testAuthorID, ParentIds: parents})
+		return err
+	}
+
+	require.NoError(t, create("base"))
+	require.NoError(t, create("middle", "base"))
+	require.NoError(t, create("top", "middle"))
+
+	assert.ErrorIs(t, create("self", "self"), validateError.DependencyCycle)
+	assert.ErrorIs(t, create("orphan", "missing"), validateError.InvalidParent)
+	_, err := svc.GetDependencyGraph(ctx, "orphan")
+	assert.ErrorIs(t, err, validateError.ErrPrNotExist, "rejected PR is rolled back")
+
+	// Родитель, уже зависящий от создаваемого PR, замкнул бы цикл
+	store.edges = append(store.edges, domain.PRDependency{ChildId: "base", ParentId: "next"})
+	assert.ErrorIs(t, create("next", "top"), validateError.DependencyCycle)
+
+	graph, err := svc.GetDependencyGraph(ctx, "middle")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRDependency{
+		{ChildId: "base", ParentId: "next"},
+		{ChildId: "middle", ParentId: "base"},
+		{ChildId: "top", ParentId: "middle"},
+	}, graph.Edges)
+	assert.Equal(t, []string{"base", "middle", "top"}, []string{graph.Nodes[0].Id, graph.Nodes[1].Id, graph.Nodes[2].Id})
+
+	_, err = svc.Merge(ctx, domain.PRMerge{Id: "top"})
+	var blocked *validateError.MergeBlockedError
+	require.ErrorAs(t, err, &blocked)
+	assert.Equal(t, []string{"base", "middle"}, blocked.Unmet[0].PullRequestIds)
+
+	for _, id := range []string{"base", "middle", "top"} {
+		_, err := svc.Merge(ctx, domain.PRMerge{Id: id})
+		assert.NoError(t, err, id)
+	}
+}
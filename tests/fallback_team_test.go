@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFallbackStore заводит команду dev, где кроме автора только u2, с резервными командами qa и ops.
+// Команда zz в резервные не входит.
+func newFallbackStore() *MemoryStore {
+	store := NewMemoryStore(fixedNow)
+	for name, ids := range map[string][]string{"qa": {"q1"}, "ops": {"o1", "o2"}, "zz": {"z1"}} {
+		team := MakeTestTeam(name, ids)
+		team.ReviewersCount = domain.DefaultReviewersCount
+		team.CapacityPolicy = domain.CapacityOverAssign
+		store.AddTeam(team)
+	}
+
+	dev := MakeTestTeam(testTeamDev, []string{testAuthorID, "u2"})
+	dev.ReviewersCount = 3
+	dev.CapacityPolicy = domain.CapacityOverAssign
+	dev.FallbackTeams = []string{"qa", "ops"}
+	store.AddTeam(dev)
+	return store
+}
+
+func TestPRService_FallbackTeams(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing reviewers are drawn from fallback teams in order", func(t *testing.T) {
+		svc := newMemoryPRService(newFallbackStore())
+
+		pr, err := svc.Create(ctx, domain.PullRequestCreate{Id: testPRID, Name: testPRName, AuthorId: testAuthorID})
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.AssignedReviewer{
+			{Id: "u2"},
+			{Id: "q1", External: true},
+			{Id: "o1", External: true},
+		}, pr.Reviewers)
+	})
+
+	t.Run("external reviewer can be reassigned", func(t *testing.T) {
+		store := newFallbackStore()
+		store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusOpen},
+			domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "q1", External: true})
+		svc := newMemoryPRService(store)
+
+		res, err := svc.Reassign(ctx, domain.PRReassign{Id: testPRID, OldUserId: "q1"})
+		require.NoError(t, err)
+		assert.Equal(t, "o1", res.ReplacedId)
+		assert.Equal(t, []domain.AssignedReviewer{{Id: "o1", External: true}, {Id: "u2"}}, res.PullRequest.Reviewers)
+	})
+
+	t.Run("non-assigned user from another team is rejected", func(t *testing.T) {
+		store := newFallbackStore()
+		store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusOpen},
+			domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "q1", External: true})
+		svc := newMemoryPRService(store)
+
+		for _, id := range []string{"o1", "z1"} {
+			_, err := svc.Reassign(ctx, domain.PRReassign{Id: testPRID, OldUserId: id})
+			assert.ErrorIs(t, err, validateError.UserNotAssignToTeam, id)
+		}
+	})
+}
+
+func TestTeamService_CreateFallbackTeams(t *testing.T) {
+	ctx := context.Background()
+	newTeam := func(fallbacks ...string) domain.Team {
+		team := MakeTestTeam(testTeamName, []string{"b1", "b2"})
+		team.ReviewersCount = domain.DefaultReviewersCount
+		team.FallbackTeams = fallbacks
+		return team
+	}
+
+	store := newFallbackStore()
+	svc := newMemoryTeamService(store, newMemoryPRService(store))
+
+	for name, team := range map[string]domain.Team{
+		"unknown":   newTeam("missing"),
+		"self":      newTeam(testTeamName),
+		"duplicate": newTeam("qa", "qa"),
+	} {
+		_, _, err := svc.Create(ctx, team)
+		assert.ErrorIs(t, err, validateError.InvalidFallbackTeam, name)
+	}
+	_, err := store.Repos().Team.GetByName(ctx, testTeamName)
+	assert.ErrorIs(t, err, validateError.TeamNotFound)
+
+	created, _, err := svc.Create(ctx, newTeam("ops", "qa"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ops", "qa"}, created.FallbackTeams)
+}
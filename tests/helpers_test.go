@@ -1,6 +1,11 @@
 package tests
 
-import "github.com/linspacestrom/InterShipAv/internal/domain"
+import (
+	"context"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/services"
+)
 
 func MakeTestTeam(name string, ids []string) domain.Team {
 	var members []domain.TeamMember
@@ -35,3 +40,69 @@ func MakeTestPR(id, name, author string, reviewers bool) domain.PullRequestRead
 		AssignReviewerIds: ids,
 	}
 }
+
+// firstSelector берёт кандидатов в порядке пула, чтобы назначение в тестах сервисов было детерминированным.
+type firstSelector struct{}
+
+func (firstSelector) Select(_ context.Context, req services.SelectRequest) ([]string, error) {
+	ids := make([]string, 0, req.Count)
+	for _, c := range req.Candidates {
+		if len(ids) == req.Count {
+			break
+		}
+		ids = append(ids, c.Id)
+	}
+	return ids, nil
+}
+
+func newMemoryPRService(store *MemoryStore) *services.PRService {
+	repos := store.Repos()
+	selectors := services.NewSelectorRegistry("first", nil)
+	selectors.Register("first", firstSelector{})
+
+	svc := services.NewPRService(repos.PR, repos.User, repos.Team, repos.CodeOwner, repos.Rule, repos.Absence,
+		repos.Decline, repos.Revision, repos.Event, selectors, fixedClock{now: fixedNow}, store.Tx())
+	return &svc
+}
+
+func newMemoryTeamService(store *MemoryStore, topUp services.ReviewerTopUp) *services.TeamService {
+	repos := store.Repos()
+	svc := services.NewTeamService(repos.Team, repos.User, repos.CodeOwner, repos.Absence, topUp, fixedClock{now: fixedNow}, store.Tx())
+	return &svc
+}
+
+func newMemoryAbsenceService(store *MemoryStore, topUp services.ReviewerTopUp) *services.AbsenceService {
+	repos := store.Repos()
+	svc := services.NewAbsenceService(repos.Absence, repos.User, topUp, store.Tx())
+	return &svc
+}
+
+// failingTopUp — добор, который всегда завершается ошибкой.
+type failingTopUp struct {
+	err error
+}
+
+func (f failingTopUp) TopUpTeam(ctx context.Context, teamName string) ([]domain.TopUp, error) {
+	return nil, f.err
+}
+
+// seedDevTeam заводит команду dev из автора u1 и ревьюеров u2–u4.
+func seedDevTeam(store *MemoryStore, team domain.Team) {
+	team.Name = testTeamDev
+	if team.ReviewersCount == 0 {
+		team.ReviewersCount = domain.DefaultReviewersCount
+	}
+	if team.CapacityPolicy == "" {
+		team.CapacityPolicy = domain.CapacityOverAssign
+	}
+	team.Members = MakeTestTeam(testTeamDev, []string{testAuthorID, "u2", "u3", "u4"}).Members
+	store.AddTeam(team)
+}
+
+func kindsOf(events []domain.PREvent) []domain.PREventKind {
+	kinds := make([]domain.PREventKind, 0, len(events))
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+	}
+	return kinds
+}
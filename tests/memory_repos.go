@@ -0,0 +1,817 @@
+package tests
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/repositories"
+	"github.com/linspacestrom/InterShipAv/internal/transaction"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+)
+
+// MemoryStore хранит данные репозиториев в памяти, чтобы сервисы можно было проверять без базы.
+// Repos отдаёт репозитории поверх одного хранилища, Tx — транзакции, откатывающие его при ошибке.
+type MemoryStore struct {
+	now        time.Time
+	nextId     int64
+	users      map[string]domain.User
+	teams      map[string]domain.Team
+	prs        map[string]domain.PullRequestRead
+	mergedAt   map[string]time.Time
+	reviewers  map[string][]domain.AssignedReviewer
+	decisions  []domain.ReviewDecision
+	edges      []domain.PRDependency
+	overrides  map[string]MemoryOverride
+	revisions  []domain.Revision
+	events     []domain.PREvent
+	rules      []domain.ReviewerRule
+	absences   []domain.Absence
+	declines   []domain.ReviewDecline
+	codeOwners map[string][]domain.CodeOwnerRule
+}
+
+// MemoryOverride — запись аудита принудительного слияния.
+type MemoryOverride struct {
+	AdminId string
+	Unmet   []domain.MergeConditionKind
+}
+
+func NewMemoryStore(now time.Time) *MemoryStore {
+	return &MemoryStore{
+		now:        now,
+		users:      make(map[string]domain.User),
+		teams:      make(map[string]domain.Team),
+		prs:        make(map[string]domain.PullRequestRead),
+		mergedAt:   make(map[string]time.Time),
+		reviewers:  make(map[string][]domain.AssignedReviewer),
+		overrides:  make(map[string]MemoryOverride),
+		codeOwners: make(map[string][]domain.CodeOwnerRule),
+	}
+}
+
+type MemoryRepos struct {
+	PR        *MemoryPrRepo
+	User      *MemoryUserRepo
+	Team      *MemoryTeamRepo
+	CodeOwner *MemoryCodeOwnerRepo
+	Rule      *MemoryRuleRepo
+	Absence   *MemoryAbsenceRepo
+	Decline   *MemoryDeclineRepo
+	Revision  *MemoryRevisionRepo
+	Event     *MemoryEventRepo
+}
+
+func (s *MemoryStore) Repos() MemoryRepos {
+	return MemoryRepos{
+		PR:        &MemoryPrRepo{s},
+		User:      &MemoryUserRepo{s},
+		Team:      &MemoryTeamRepo{s},
+		CodeOwner: &MemoryCodeOwnerRepo{s},
+		Rule:      &MemoryRuleRepo{s},
+		Absence:   &MemoryAbsenceRepo{s},
+		Decline:   &MemoryDeclineRepo{s},
+		Revision:  &MemoryRevisionRepo{s},
+		Event:     &MemoryEventRepo{s},
+	}
+}
+
+func (s *MemoryStore) id() int64 {
+	s.nextId++
+	return s.nextId
+}
+
+// snapshot копирует хранилище настолько глубоко, насколько его меняют репозитории.
+func (s *MemoryStore) snapshot() MemoryStore {
+	c := *s
+	c.users = maps.Clone(s.users)
+	c.teams = maps.Clone(s.teams)
+	c.prs = maps.Clone(s.prs)
+	c.mergedAt = maps.Clone(s.mergedAt)
+	c.reviewers = make(map[string][]domain.AssignedReviewer, len(s.reviewers))
+	for id, r := range s.reviewers {
+		c.reviewers[id] = slices.Clone(r)
+	}
+	c.decisions = slices.Clone(s.decisions)
+	c.edges = slices.Clone(s.edges)
+	c.overrides = maps.Clone(s.overrides)
+	c.revisions = slices.Clone(s.revisions)
+	c.events = slices.Clone(s.events)
+	c.rules = slices.Clone(s.rules)
+	c.absences = slices.Clone(s.absences)
+	c.declines = slices.Clone(s.declines)
+	c.codeOwners = maps.Clone(s.codeOwners)
+	return c
+}
+
+// AddTeam заводит команду с участниками так же, как team/add.
+func (s *MemoryStore) AddTeam(team domain.Team) {
+	members := team.Members
+	team.Members = nil
+	s.teams[team.Name] = team
+	_ = (&MemoryUserRepo{s}).AddUsersToTeam(context.Background(), members, team.Name)
+}
+
+// AddPR заводит PR с уже назначенными ревьюерами.
+func (s *MemoryStore) AddPR(pr domain.PullRequestRead, reviewers ...domain.AssignedReviewer) {
+	pr.Reviewers = nil
+	pr.AssignReviewerIds = nil
+	s.prs[pr.Id] = pr
+	if len(reviewers) > 0 {
+		s.reviewers[pr.Id] = slices.Clone(reviewers)
+	}
+}
+
+func (s *MemoryStore) User(id string) domain.User {
+	return s.users[id]
+}
+
+func (s *MemoryStore) Events(prId string) []domain.PREvent {
+	events, _ := (&MemoryEventRepo{s}).GetByPullRequestId(context.Background(), prId)
+	return events
+}
+
+func (s *MemoryStore) Override(prId string) (MemoryOverride, bool) {
+	o, ok := s.overrides[prId]
+	return o, ok
+}
+
+// MemoryTx — транзакции поверх MemoryStore: при ошибке внешней транзакции хранилище
+// возвращается к состоянию на её начало, вложенные вызовы выполняются в ней же.
+type MemoryTx struct {
+	store *MemoryStore
+}
+
+type memoryTxKey struct{}
+
+var _ transaction.Runner = (*MemoryTx)(nil)
+
+func (s *MemoryStore) Tx() *MemoryTx {
+	return &MemoryTx{store: s}
+}
+
+func (t *MemoryTx) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	saved := t.store.snapshot()
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, true)); err != nil {
+		*t.store = saved
+		return err
+	}
+	return nil
+}
+
+type MemoryUserRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.UserRepo = (*MemoryUserRepo)(nil)
+
+func (r *MemoryUserRepo) AddUsersToTeam(ctx context.Context, users []domain.TeamMember, teamName string) error {
+	for _, m := range users {
+		u, ok := r.store.users[m.ID]
+		if !ok {
+			u = domain.User{Id: m.ID, Level: domain.DefaultLevel}
+		}
+		u.Username = m.Username
+		u.TeamName = teamName
+		u.IsActive = m.IsActive
+		if m.Level != nil {
+			u.Level = *m.Level
+		}
+		if m.IsAdmin != nil {
+			u.IsAdmin = *m.IsAdmin
+		}
+		r.store.users[m.ID] = u
+	}
+	return nil
+}
+
+func (r *MemoryUserRepo) GetUserByTeamName(ctx context.Context, name string) ([]domain.TeamMember, error) {
+	var members []domain.TeamMember
+	for _, id := range slices.Sorted(maps.Keys(r.store.users)) {
+		u := r.store.users[id]
+		if u.TeamName != name {
+			continue
+		}
+		level, isAdmin := u.Level, u.IsAdmin
+		members = append(members, domain.TeamMember{ID: u.Id, Username: u.Username, IsActive: u.IsActive, Level: &level, IsAdmin: &isAdmin})
+	}
+	return members, nil
+}
+
+func (r *MemoryUserRepo) GetById(ctx context.Context, id string) (domain.User, error) {
+	u, ok := r.store.users[id]
+	if !ok {
+		return domain.User{}, validateError.UserNotFound
+	}
+	return u, nil
+}
+
+func (r *MemoryUserRepo) update(id string, change func(u *domain.User)) (domain.User, error) {
+	u, ok := r.store.users[id]
+	if !ok {
+		return domain.User{}, validateError.UserNotFound
+	}
+	change(&u)
+	r.store.users[id] = u
+	return u, nil
+}
+
+func (r *MemoryUserRepo) SetActiveById(ctx context.Context, id string, isActive bool) (domain.User, error) {
+	return r.update(id, func(u *domain.User) { u.IsActive = isActive })
+}
+
+func (r *MemoryUserRepo) SetMaxOpenReviewsById(ctx context.Context, id string, maxOpenReviews *int) (domain.User, error) {
+	return r.update(id, func(u *domain.User) { u.MaxOpenReviews = maxOpenReviews })
+}
+
+func (r *MemoryUserRepo) SetScheduleById(ctx context.Context, id string, schedule domain.WorkSchedule) (domain.User, error) {
+	return r.update(id, func(u *domain.User) { u.Schedule = schedule })
+}
+
+func (r *MemoryUserRepo) GetNewReviewers(ctx context.Context, name string, excludeUserId string, now time.Time) ([]domain.ReviewerCandidate, error) {
+	return r.candidates(excludeUserId, now, func(u domain.User) bool { return u.TeamName == name }), nil
+}
+
+func (r *MemoryUserRepo) GetNewReviewer(ctx context.Context, authorId string, teamName string, reviewersIds []string, now time.Time) ([]domain.ReviewerCandidate, error) {
+	return r.candidates(authorId, now, func(u domain.User) bool {
+		return u.TeamName == teamName && !slices.Contains(reviewersIds, u.Id)
+	}), nil
+}
+
+func (r *MemoryUserRepo) GetCandidatesByIds(ctx context.Context, ids []string, excludeUserId string, now time.Time) ([]domain.ReviewerCandidate, error) {
+	return r.candidates(excludeUserId, now, func(u domain.User) bool { return slices.Contains(ids, u.Id) }), nil
+}
+
+// candidates повторяет candidateFilter репозитория: активные, не автор, без запрета на ревью автора
+// и без отсутствия в момент now.
+func (r *MemoryUserRepo) candidates(authorId string, now time.Time, match func(domain.User) bool) []domain.ReviewerCandidate {
+	var candidates []domain.ReviewerCandidate
+	for _, id := range slices.Sorted(maps.Keys(r.store.users)) {
+		u := r.store.users[id]
+		if !u.IsActive || u.Id == authorId || !match(u) {
+			continue
+		}
+		if domain.ExcludesReviewer(r.store.rules, u.Id, authorId) || domain.AbsentAt(r.store.userAbsences(u.Id), now) {
+			continue
+		}
+
+		candidates = append(candidates, domain.ReviewerCandidate{
+			Id:             u.Id,
+			TeamName:       u.TeamName,
+			OpenReviews:    r.store.openReviews(u.Id),
+			MaxOpenReviews: u.MaxOpenReviews,
+			Level:          u.Level,
+			Schedule:       u.Schedule,
+			Preferred: slices.ContainsFunc(r.store.rules, func(rule domain.ReviewerRule) bool {
+				return rule.ReviewerId == u.Id && rule.AuthorId == authorId && rule.Kind == domain.RulePrefer
+			}),
+		})
+	}
+	return candidates
+}
+
+func (s *MemoryStore) openReviews(userId string) int {
+	count := 0
+	for prId, reviewers := range s.reviewers {
+		if s.prs[prId].Status == domain.StatusOpen && containsAssigned(reviewers, userId) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *MemoryStore) userAbsences(userId string) []domain.Absence {
+	var absences []domain.Absence
+	for _, a := range s.absences {
+		if a.UserId == userId {
+			absences = append(absences, a)
+		}
+	}
+	return absences
+}
+
+func containsAssigned(reviewers []domain.AssignedReviewer, id string) bool {
+	return slices.ContainsFunc(reviewers, func(r domain.AssignedReviewer) bool { return r.Id == id })
+}
+
+type MemoryTeamRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.TeamRepo = (*MemoryTeamRepo)(nil)
+
+func (r *MemoryTeamRepo) Create(ctx context.Context, team domain.Team) (domain.Team, error) {
+	team.Members = nil
+	r.store.teams[team.Name] = team
+	return team, nil
+}
+
+func (r *MemoryTeamRepo) GetByName(ctx context.Context, name string) (domain.Team, error) {
+	team, ok := r.store.teams[name]
+	if !ok {
+		return domain.Team{}, validateError.TeamNotFound
+	}
+	return team, nil
+}
+
+func (r *MemoryTeamRepo) LockRotation(ctx context.Context, name string) ([]domain.RotationEntry, int, error) {
+	if _, ok := r.store.teams[name]; !ok {
+		return nil, 0, validateError.TeamNotFound
+	}
+	return nil, 0, nil
+}
+
+func (r *MemoryTeamRepo) SetRotationCursor(ctx context.Context, name string, cursor int) error {
+	return nil
+}
+
+type MemoryPrRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.PrRepo = (*MemoryPrRepo)(nil)
+
+func (r *MemoryPrRepo) Create(ctx context.Context, pr domain.PullRequestCreate) (domain.PullRequestRead, error) {
+	created := domain.PullRequestRead{
+		Id:          pr.Id,
+		Name:        pr.Name,
+		AuthorId:    pr.AuthorId,
+		Status:      domain.StatusOpen,
+		Description: pr.Description,
+		ExternalURL: pr.ExternalURL,
+		Labels:      domain.NormalizeLabels(pr.Labels),
+		Priority:    cmp.Or(pr.Priority, domain.PriorityNormal),
+	}
+	if pr.Draft {
+		created.Status = domain.StatusDraft
+	}
+	r.store.prs[pr.Id] = created
+	return created, nil
+}
+
+func (r *MemoryPrRepo) Merge(ctx context.Context, prId string) (domain.PRMergeRead, error) {
+	pr, err := r.SetStatus(ctx, prId, domain.StatusMerged)
+	if err != nil {
+		return domain.PRMergeRead{}, err
+	}
+	if _, ok := r.store.mergedAt[prId]; !ok {
+		r.store.mergedAt[prId] = r.store.now
+	}
+	mergedAt := r.store.mergedAt[prId]
+
+	return domain.PRMergeRead{Id: pr.Id, Name: pr.Name, AuthorId: pr.AuthorId, Status: pr.Status, Labels: pr.Labels, Priority: pr.Priority, MergedAt: &mergedAt}, nil
+}
+
+func (r *MemoryPrRepo) Update(ctx context.Context, update domain.PRUpdate) (domain.PullRequestRead, error) {
+	pr, ok := r.store.prs[update.Id]
+	if !ok {
+		return pr, validateError.ErrPrNotExist
+	}
+	if update.Name != nil {
+		pr.Name = *update.Name
+	}
+	if update.Description != nil {
+		pr.Description = *update.Description
+	}
+	if update.ExternalURL != nil {
+		pr.ExternalURL = *update.ExternalURL
+	}
+	if update.AuthorId != nil {
+		pr.AuthorId = *update.AuthorId
+	}
+	if update.Labels != nil {
+		pr.Labels = domain.NormalizeLabels(*update.Labels)
+	}
+	if update.Priority != nil {
+		pr.Priority = *update.Priority
+	}
+	r.store.prs[pr.Id] = pr
+	return pr, nil
+}
+
+func (r *MemoryPrRepo) AddParents(ctx context.Context, prId string, parentIds []string) error {
+	for _, parentId := range parentIds {
+		edge := domain.PRDependency{ChildId: prId, ParentId: parentId}
+		if !slices.Contains(r.store.edges, edge) {
+			r.store.edges = append(r.store.edges, edge)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryPrRepo) GetDependencyGraph(ctx context.Context, prId string) (domain.PRDependencyGraph, error) {
+	graph := domain.PRDependencyGraph{Id: prId, Nodes: make([]domain.PRGraphNode, 0), Edges: make([]domain.PRDependency, 0)}
+
+	ids := map[string]bool{prId: true}
+	for _, up := range []bool{true, false} {
+		frontier := []string{prId}
+		for len(frontier) > 0 {
+			id := frontier[0]
+			frontier = frontier[1:]
+			for _, e := range r.store.edges {
+				from, to := e.ChildId, e.ParentId
+				if !up {
+					from, to = e.ParentId, e.ChildId
+				}
+				if from != id || slices.Contains(graph.Edges, e) {
+					continue
+				}
+				graph.Edges = append(graph.Edges, e)
+				ids[to] = true
+				frontier = append(frontier, to)
+			}
+		}
+	}
+
+	slices.SortFunc(graph.Edges, func(a, b domain.PRDependency) int {
+		return cmp.Or(cmp.Compare(a.ChildId, b.ChildId), cmp.Compare(a.ParentId, b.ParentId))
+	})
+	for _, id := range slices.Sorted(maps.Keys(ids)) {
+		if pr, ok := r.store.prs[id]; ok {
+			graph.Nodes = append(graph.Nodes, domain.PRGraphNode{Id: pr.Id, Name: pr.Name, Status: pr.Status})
+		}
+	}
+	return graph, nil
+}
+
+func (r *MemoryPrRepo) SetStatus(ctx context.Context, prId string, status domain.PullRequestStatus) (domain.PullRequestRead, error) {
+	pr, ok := r.store.prs[prId]
+	if !ok {
+		return pr, validateError.ErrPrNotExist
+	}
+	pr.Status = status
+	r.store.prs[prId] = pr
+	return pr, nil
+}
+
+func (r *MemoryPrRepo) RemoveReviewers(ctx context.Context, prId string) error {
+	delete(r.store.reviewers, prId)
+	return nil
+}
+
+func (r *MemoryPrRepo) RecordForcedMerge(ctx context.Context, prId string, adminId string, unmet []domain.MergeCondition) error {
+	if _, ok := r.store.overrides[prId]; ok {
+		return nil
+	}
+	kinds := make([]domain.MergeConditionKind, 0, len(unmet))
+	for _, c := range unmet {
+		kinds = append(kinds, c.Kind)
+	}
+	r.store.overrides[prId] = MemoryOverride{AdminId: adminId, Unmet: kinds}
+	return nil
+}
+
+func (r *MemoryPrRepo) GetById(ctx context.Context, id string) (domain.PullRequestRead, error) {
+	pr, ok := r.store.prs[id]
+	if !ok {
+		return pr, validateError.ErrPrNotExist
+	}
+	return pr, nil
+}
+
+func (r *MemoryPrRepo) AssignReviewers(ctx context.Context, prId string, reviewers []domain.AssignedReviewer) ([]domain.AssignedReviewer, error) {
+	assigned := make([]domain.AssignedReviewer, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		reviewer.Decision = nil
+		assigned = append(assigned, reviewer)
+	}
+	r.store.reviewers[prId] = append(r.store.reviewers[prId], assigned...)
+	return assigned, nil
+}
+
+func (r *MemoryPrRepo) GetPullRequestIdsByReviewerId(ctx context.Context, userId string) ([]string, error) {
+	var ids []string
+	for _, prId := range slices.Sorted(maps.Keys(r.store.reviewers)) {
+		if containsAssigned(r.store.reviewers[prId], userId) {
+			ids = append(ids, prId)
+		}
+	}
+	return ids, nil
+}
+
+func (r *MemoryPrRepo) GetPullRequestsByIds(ctx context.Context, prIds []string) ([]domain.PullRequestReviewRead, error) {
+	reviews := make([]domain.PullRequestReviewRead, 0, len(prIds))
+	for _, id := range prIds {
+		pr, ok := r.store.prs[id]
+		if !ok || pr.Status != domain.StatusOpen {
+			continue
+		}
+		reviews = append(reviews, domain.PullRequestReviewRead{Id: pr.Id, Name: pr.Name, AuthorId: pr.AuthorId, Status: pr.Status, Labels: pr.Labels, Priority: pr.Priority, CreatedAt: r.store.now})
+	}
+	return reviews, nil
+}
+
+func (r *MemoryPrRepo) GetReviewersById(ctx context.Context, id string) ([]domain.AssignedReviewer, error) {
+	reviewers := slices.Clone(r.store.reviewers[id])
+	slices.SortFunc(reviewers, func(a, b domain.AssignedReviewer) int { return cmp.Compare(a.Id, b.Id) })
+
+	latest := make(map[string]domain.ReviewDecision)
+	for _, d := range r.store.decisions {
+		if d.PullRequestId == id {
+			latest[d.ReviewerId] = d
+		}
+	}
+	for i := range reviewers {
+		if d, ok := latest[reviewers[i].Id]; ok {
+			reviewers[i].Decision = &d
+		}
+	}
+	return reviewers, nil
+}
+
+func (r *MemoryPrRepo) Reassign(ctx context.Context, prId string, newReviewer domain.AssignedReviewer, oldReviewerId string) error {
+	reviewers := r.store.reviewers[prId]
+	for i := range reviewers {
+		if reviewers[i].Id == oldReviewerId {
+			newReviewer.Decision = nil
+			reviewers[i] = newReviewer
+		}
+	}
+	return nil
+}
+
+func (r *MemoryPrRepo) RemoveReviewer(ctx context.Context, prId string, reviewerId string) error {
+	r.store.reviewers[prId] = slices.DeleteFunc(r.store.reviewers[prId], func(a domain.AssignedReviewer) bool { return a.Id == reviewerId })
+	return nil
+}
+
+func (r *MemoryPrRepo) SetReviewerExternal(ctx context.Context, prId string, reviewerId string, external bool) error {
+	reviewers := r.store.reviewers[prId]
+	for i := range reviewers {
+		if reviewers[i].Id == reviewerId {
+			reviewers[i].External = external
+		}
+	}
+	return nil
+}
+
+func (r *MemoryPrRepo) GetRecentReviewCounts(ctx context.Context, authorId string, since time.Time) (map[string]int, error) {
+	counts := make(map[string]int)
+	for prId, reviewers := range r.store.reviewers {
+		if r.store.prs[prId].AuthorId != authorId {
+			continue
+		}
+		for _, reviewer := range reviewers {
+			counts[reviewer.Id]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *MemoryPrRepo) GetUnderstaffedByTeamName(ctx context.Context, teamName string) ([]domain.PullRequestRead, error) {
+	prs := make([]domain.PullRequestRead, 0)
+	for _, id := range slices.Sorted(maps.Keys(r.store.prs)) {
+		pr := r.store.prs[id]
+		if r.understaffed(pr) && r.store.users[pr.AuthorId].TeamName == teamName {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+func (r *MemoryPrRepo) GetUnderstaffedTeamNames(ctx context.Context) ([]string, error) {
+	names := make(map[string]bool)
+	for _, pr := range r.store.prs {
+		if r.understaffed(pr) {
+			names[r.store.users[pr.AuthorId].TeamName] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(names)), nil
+}
+
+func (r *MemoryPrRepo) understaffed(pr domain.PullRequestRead) bool {
+	team := r.store.teams[r.store.users[pr.AuthorId].TeamName]
+	return pr.Status == domain.StatusOpen && len(r.store.reviewers[pr.Id]) < team.ReviewersCount
+}
+
+func (r *MemoryPrRepo) AddDecision(ctx context.Context, decision domain.ReviewDecision) (domain.ReviewDecision, error) {
+	decision.Id = r.store.id()
+	decision.SubmittedAt = r.store.now
+	decision.RevisionId = nil
+	decision.CommitSHA = ""
+	for _, rev := range r.store.revisions {
+		if rev.PullRequestId == decision.PullRequestId {
+			decision.RevisionId = &rev.Id
+			decision.CommitSHA = rev.CommitSHA
+		}
+	}
+	r.store.decisions = append(r.store.decisions, decision)
+	return decision, nil
+}
+
+func (r *MemoryPrRepo) GetDecisionsById(ctx context.Context, prId string) ([]domain.ReviewDecision, error) {
+	decisions := make([]domain.ReviewDecision, 0)
+	for _, d := range r.store.decisions {
+		if d.PullRequestId == prId {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions, nil
+}
+
+func (r *MemoryPrRepo) GetLatestDecisionsByReviewerId(ctx context.Context, reviewerId string) (map[string]domain.ReviewDecision, error) {
+	decisions := make(map[string]domain.ReviewDecision)
+	for _, d := range r.store.decisions {
+		if d.ReviewerId == reviewerId {
+			decisions[d.PullRequestId] = d
+		}
+	}
+	return decisions, nil
+}
+
+func (r *MemoryPrRepo) MarkDecisionsStale(ctx context.Context, prId string, revisionId int64, kinds []domain.ReviewDecisionKind) ([]domain.ReviewDecision, error) {
+	stale := make([]domain.ReviewDecision, 0)
+	for i, d := range r.store.decisions {
+		if d.PullRequestId != prId || d.Stale || !slices.Contains(kinds, d.Decision) {
+			continue
+		}
+		if d.RevisionId != nil && *d.RevisionId == revisionId {
+			continue
+		}
+		r.store.decisions[i].Stale = true
+		stale = append(stale, r.store.decisions[i])
+	}
+	return stale, nil
+}
+
+type MemoryCodeOwnerRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.CodeOwnerRepo = (*MemoryCodeOwnerRepo)(nil)
+
+func (r *MemoryCodeOwnerRepo) ReplaceRules(ctx context.Context, teamName string, rules []domain.CodeOwnerRule) error {
+	r.store.codeOwners[teamName] = slices.Clone(rules)
+	return nil
+}
+
+func (r *MemoryCodeOwnerRepo) GetRulesByTeamName(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	return slices.Clone(r.store.codeOwners[teamName]), nil
+}
+
+type MemoryRuleRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.ReviewerRuleRepo = (*MemoryRuleRepo)(nil)
+
+func (r *MemoryRuleRepo) Upsert(ctx context.Context, rule domain.ReviewerRule) (domain.ReviewerRule, error) {
+	_ = r.Delete(ctx, rule.ReviewerId, rule.AuthorId)
+	r.store.rules = append(r.store.rules, rule)
+	return rule, nil
+}
+
+func (r *MemoryRuleRepo) Delete(ctx context.Context, reviewerId string, authorId string) error {
+	before := len(r.store.rules)
+	r.store.rules = slices.DeleteFunc(r.store.rules, func(rule domain.ReviewerRule) bool {
+		return rule.ReviewerId == reviewerId && rule.AuthorId == authorId
+	})
+	if len(r.store.rules) == before {
+		return validateError.ReviewerRuleNotFound
+	}
+	return nil
+}
+
+func (r *MemoryRuleRepo) GetByUserId(ctx context.Context, userId string) ([]domain.ReviewerRule, error) {
+	rules := make([]domain.ReviewerRule, 0)
+	for _, rule := range r.store.rules {
+		if rule.ReviewerId == userId || rule.AuthorId == userId {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+type MemoryAbsenceRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.AbsenceRepo = (*MemoryAbsenceRepo)(nil)
+
+func (r *MemoryAbsenceRepo) Create(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	absence.Id = r.store.id()
+	r.store.absences = append(r.store.absences, absence)
+	return absence, nil
+}
+
+func (r *MemoryAbsenceRepo) Delete(ctx context.Context, id int64) (domain.Absence, error) {
+	i := slices.IndexFunc(r.store.absences, func(a domain.Absence) bool { return a.Id == id })
+	if i < 0 {
+		return domain.Absence{}, validateError.AbsenceNotFound
+	}
+	deleted := r.store.absences[i]
+	r.store.absences = slices.Delete(r.store.absences, i, i+1)
+	return deleted, nil
+}
+
+func (r *MemoryAbsenceRepo) GetByUserId(ctx context.Context, userId string) ([]domain.Absence, error) {
+	return r.store.userAbsences(userId), nil
+}
+
+func (r *MemoryAbsenceRepo) GetActualByTeamName(ctx context.Context, teamName string, now time.Time) ([]domain.Absence, error) {
+	absences := make([]domain.Absence, 0)
+	for _, a := range r.store.absences {
+		if r.store.users[a.UserId].TeamName == teamName && a.EndsAt.After(now) {
+			absences = append(absences, a)
+		}
+	}
+	return absences, nil
+}
+
+type MemoryDeclineRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.DeclineRepo = (*MemoryDeclineRepo)(nil)
+
+func (r *MemoryDeclineRepo) Create(ctx context.Context, decline domain.PRDecline) error {
+	r.store.declines = append(r.store.declines, domain.ReviewDecline{
+		PullRequestId: decline.Id,
+		ReviewerId:    decline.UserId,
+		TeamName:      r.store.users[decline.UserId].TeamName,
+		Reason:        decline.Reason,
+		Comment:       decline.Comment,
+		DeclinedAt:    r.store.now,
+	})
+	return nil
+}
+
+func (r *MemoryDeclineRepo) GetReviewerIdsByPR(ctx context.Context, prId string) ([]string, error) {
+	ids := make([]string, 0)
+	for _, d := range r.store.declines {
+		if d.PullRequestId == prId {
+			ids = append(ids, d.ReviewerId)
+		}
+	}
+	return ids, nil
+}
+
+func (r *MemoryDeclineRepo) GetByUserId(ctx context.Context, userId string) ([]domain.ReviewDecline, error) {
+	return r.filter(func(d domain.ReviewDecline) bool { return d.ReviewerId == userId }), nil
+}
+
+func (r *MemoryDeclineRepo) GetByTeamName(ctx context.Context, teamName string) ([]domain.ReviewDecline, error) {
+	return r.filter(func(d domain.ReviewDecline) bool { return d.TeamName == teamName }), nil
+}
+
+func (r *MemoryDeclineRepo) filter(keep func(domain.ReviewDecline) bool) []domain.ReviewDecline {
+	declines := make([]domain.ReviewDecline, 0)
+	for _, d := range slices.Backward(r.store.declines) {
+		if keep(d) {
+			declines = append(declines, d)
+		}
+	}
+	return declines
+}
+
+type MemoryRevisionRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.RevisionRepo = (*MemoryRevisionRepo)(nil)
+
+func (r *MemoryRevisionRepo) Create(ctx context.Context, revision domain.Revision) (domain.Revision, error) {
+	revision.Id = r.store.id()
+	r.store.revisions = append(r.store.revisions, revision)
+	return revision, nil
+}
+
+func (r *MemoryRevisionRepo) GetByPullRequestId(ctx context.Context, prId string) ([]domain.Revision, error) {
+	revisions := make([]domain.Revision, 0)
+	for _, rev := range r.store.revisions {
+		if rev.PullRequestId == prId {
+			revisions = append(revisions, rev)
+		}
+	}
+	return revisions, nil
+}
+
+type MemoryEventRepo struct {
+	store *MemoryStore
+}
+
+var _ repositories.EventRepo = (*MemoryEventRepo)(nil)
+
+func (r *MemoryEventRepo) Create(ctx context.Context, event domain.PREvent) error {
+	event.Id = r.store.id()
+	event.CreatedAt = r.store.now
+	r.store.events = append(r.store.events, event)
+	return nil
+}
+
+func (r *MemoryEventRepo) GetByPullRequestId(ctx context.Context, prId string) ([]domain.PREvent, error) {
+	events := make([]domain.PREvent, 0)
+	for _, e := range r.store.events {
+		if e.PullRequestId == prId {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
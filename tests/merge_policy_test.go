@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reviewerWithDecision(id string, kind domain.ReviewDecisionKind) domain.AssignedReviewer {
+	return domain.AssignedReviewer{Id: id, Decision: &domain.ReviewDecision{ReviewerId: id, Decision: kind}}
+}
+
+func TestUnmetMergeConditions(t *testing.T) {
+	team := domain.Team{RequiredApprovals: 2}
+
+	t.Run("all conditions met", func(t *testing.T) {
+		reviewers := []domain.AssignedReviewer{
+			reviewerWithDecision("rev1", domain.DecisionApproved),
+			reviewerWithDecision("rev2", domain.DecisionApproved),
+			reviewerWithDecision("rev3", domain.DecisionCommented),
+		}
+		assert.Empty(t, team.UnmetMergeConditions("author", reviewers))
+	})
+
+	t.Run("reports missing approvals and outstanding change requests", func(t *testing.T) {
+		reviewers := []domain.AssignedReviewer{
+			reviewerWithDecision("rev1", domain.DecisionApproved),
+			reviewerWithDecision("rev2", domain.DecisionChangesRequested),
+			{Id: "rev3"},
+		}
+		assert.Equal(t, []domain.MergeCondition{
+			{Kind: domain.ConditionApprovals, Required: 2, Actual: 1},
+			{Kind: domain.ConditionChangesRequested, ReviewerIds: []string{"rev2"}},
+		}, team.UnmetMergeConditions("author", reviewers))
+	})
+
+	t.Run("author approval is not counted", func(t *testing.T) {
+		reviewers := []domain.AssignedReviewer{
+			reviewerWithDecision("author", domain.DecisionApproved),
+			reviewerWithDecision("rev1", domain.DecisionApproved),
+		}
+		unmet := team.UnmetMergeConditions("author", reviewers)
+		assert.Equal(t, []domain.MergeCondition{{Kind: domain.ConditionApprovals, Required: 2, Actual: 1}}, unmet)
+	})
+
+	t.Run("zero required approvals merges without reviewers", func(t *testing.T) {
+		assert.Empty(t, domain.Team{}.UnmetMergeConditions("author", nil))
+	})
+}
+
+func TestUnmetMergeConditions_StaleDecisions(t *testing.T) {
+	team := domain.Team{RequiredApprovals: 1}
+	stale := reviewerWithDecision("rev1", domain.DecisionApproved)
+	stale.Decision.Stale = true
+
+	assert.Equal(t, []domain.MergeCondition{{Kind: domain.ConditionApprovals, Required: 1, Actual: 0}},
+		team.UnmetMergeConditions("author", []domain.AssignedReviewer{stale}))
+}
+
+func TestStaleReviewPolicy_DismissedKinds(t *testing.T) {
+	assert.Len(t, domain.StaleDismissAll.DismissedKinds(), 3)
+	assert.Equal(t, []domain.ReviewDecisionKind{domain.DecisionApproved}, domain.StaleDismissApprovals.DismissedKinds())
+	assert.Empty(t, domain.StaleKeep.DismissedKinds())
+}
+
+func newMergeStore(t *testing.T) *MemoryStore {
+	t.Helper()
+	store := NewMemoryStore(fixedNow)
+	seedDevTeam(store, domain.Team{RequiredApprovals: 1})
+	admin := store.User("u4")
+	admin.IsAdmin = true
+	store.users[admin.Id] = admin
+
+	store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusOpen},
+		domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "u3"})
+	return store
+}
+
+func TestPRService_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("blocks merge with unmet policy", func(t *testing.T) {
+		store := newMergeStore(t)
+		svc := newMemoryPRService(store)
+
+		_, err := svc.Merge(ctx, domain.PRMerge{Id: testPRID})
+		var blocked *validateError.MergeBlockedError
+		require.ErrorAs(t, err, &blocked)
+		assert.Equal(t, []domain.MergeCondition{{Kind: domain.ConditionApprovals, Required: 1, Actual: 0}}, blocked.Unmet)
+		assert.Equal(t, domain.StatusOpen, store.prs[testPRID].Status)
+		assert.Empty(t, store.Events(testPRID))
+	})
+
+	t.Run("merges once approvals are in", func(t *testing.T) {
+		store := newMergeStore(t)
+		svc := newMemoryPRService(store)
+		_, _, err := svc.SubmitReview(ctx, domain.ReviewDecision{PullRequestId: testPRID, ReviewerId: "u2", Decision: domain.DecisionApproved})
+		require.NoError(t, err)
+
+		merged, err := svc.Merge(ctx, domain.PRMerge{Id: testPRID})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.Empty(t, merged.ForcedBy)
+		assert.Empty(t, merged.Unmet)
+
+		_, forced := store.Override(testPRID)
+		assert.False(t, forced)
+		events := store.Events(testPRID)
+		assert.Equal(t, domain.EventMerged, events[len(events)-1].Kind)
+		assert.Empty(t, events[len(events)-1].Details)
+	})
+
+	t.Run("admin force records the override and a forced merge event", func(t *testing.T) {
+		store := newMergeStore(t)
+		svc := newMemoryPRService(store)
+
+		merged, err := svc.Merge(ctx, domain.PRMerge{Id: testPRID, Force: true, UserId: "u4"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.Equal(t, "u4", merged.ForcedBy)
+		assert.Equal(t, []domain.MergeCondition{{Kind: domain.ConditionApprovals, Required: 1, Actual: 0}}, merged.Unmet)
+
+		override, ok := store.Override(testPRID)
+		require.True(t, ok)
+		assert.Equal(t, MemoryOverride{AdminId: "u4", Unmet: []domain.MergeConditionKind{domain.ConditionApprovals}}, override)
+
+		events := store.Events(testPRID)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.PREvent{Id: events[0].Id, PullRequestId: testPRID, Kind: domain.EventMerged, ActorId: "u4", Details: "forced", CreatedAt: fixedNow}, events[0])
+	})
+
+	t.Run("force by a non-admin is rejected without side effects", func(t *testing.T) {
+		store := newMergeStore(t)
+		svc := newMemoryPRService(store)
+
+		_, err := svc.Merge(ctx, domain.PRMerge{Id: testPRID, Force: true, UserId: "u2"})
+		assert.ErrorIs(t, err, validateError.ForceMergeNotAllowed)
+		assert.Equal(t, domain.StatusOpen, store.prs[testPRID].Status)
+		_, forced := store.Override(testPRID)
+		assert.False(t, forced)
+	})
+
+	t.Run("stack order is checked before the policy and force does not bypass it", func(t *testing.T) {
+		store := newMergeStore(t)
+		store.AddPR(domain.PullRequestRead{Id: testPRID2, Name: "Base", AuthorId: testAuthorID, Status: domain.StatusOpen})
+		store.edges = append(store.edges, domain.PRDependency{ChildId: testPRID, ParentId: testPRID2})
+		svc := newMemoryPRService(store)
+
+		for _, merge := range []domain.PRMerge{{Id: testPRID}, {Id: testPRID, Force: true, UserId: "u4"}} {
+			_, err := svc.Merge(ctx, merge)
+			var blocked *validateError.MergeBlockedError
+			require.ErrorAs(t, err, &blocked)
+			assert.Equal(t, []domain.MergeCondition{{Kind: domain.ConditionParentsMerged, PullRequestIds: []string{testPRID2}}}, blocked.Unmet)
+		}
+		_, forced := store.Override(testPRID)
+		assert.False(t, forced)
+
+		// Закрытый родитель стек не держит
+		_, err := svc.Close(ctx, domain.PRStatusChange{Id: testPRID2})
+		require.NoError(t, err)
+		_, err = svc.Merge(ctx, domain.PRMerge{Id: testPRID, Force: true, UserId: "u4"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("draft and closed PRs cannot be merged", func(t *testing.T) {
+		for _, status := range []domain.PullRequestStatus{domain.StatusDraft, domain.StatusClosed} {
+			store := newMergeStore(t)
+			pr := store.prs[testPRID]
+			pr.Status = status
+			store.prs[testPRID] = pr
+			svc := newMemoryPRService(store)
+
+			_, err := svc.Merge(ctx, domain.PRMerge{Id: testPRID, Force: true, UserId: "u4"})
+			assert.ErrorIs(t, err, validateError.InvalidStatusTransition, status)
+			assert.Equal(t, status, store.prs[testPRID].Status)
+		}
+	})
+
+	t.Run("repeated merge skips the policy and the event", func(t *testing.T) {
+		store := newMergeStore(t)
+		svc := newMemoryPRService(store)
+		_, err := svc.Merge(ctx, domain.PRMerge{Id: testPRID, Force: true, UserId: "u4"})
+		require.NoError(t, err)
+
+		merged, err := svc.Merge(ctx, domain.PRMerge{Id: testPRID})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.Equal(t, fixedNow, *merged.MergedAt)
+		assert.Len(t, store.Events(testPRID), 1)
+	})
+}
@@ -3,14 +3,19 @@ package tests
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/linspacestrom/InterShipAv/internal/domain"
 	"github.com/linspacestrom/InterShipAv/internal/services"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
 )
 
 type FakeTeamService struct {
 	createCalls map[string]int
+	topUpErr    error
 	lock        sync.Mutex
 }
 
@@ -20,14 +25,28 @@ func NewFakeTeamService() *FakeTeamService {
 
 var _ services.TeamSer = (*FakeTeamService)(nil)
 
-func (s *FakeTeamService) Create(ctx context.Context, team domain.Team) (domain.Team, error) {
+func (s *FakeTeamService) Create(ctx context.Context, team domain.Team) (domain.Team, []domain.TopUp, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	s.createCalls[team.Name]++
 	if s.createCalls[team.Name] > 1 {
-		return domain.Team{}, errors.New("team already exists")
+		return domain.Team{}, nil, errors.New("team already exists")
 	}
-	return team, nil
+	// Как и сервис, отдаёт участников с сохранёнными значениями: пропущенные поля — по умолчанию
+	for i := range team.Members {
+		if team.Members[i].Level == nil {
+			level := domain.DefaultLevel
+			team.Members[i].Level = &level
+		}
+		if team.Members[i].IsAdmin == nil {
+			isAdmin := false
+			team.Members[i].IsAdmin = &isAdmin
+		}
+	}
+	if s.topUpErr != nil {
+		return team, nil, fmt.Errorf("%w: %w", validateError.TopUpFailed, s.topUpErr)
+	}
+	return team, nil, nil
 }
 
 func (s *FakeTeamService) GetByName(ctx context.Context, name string) (domain.Team, error) {
@@ -39,9 +58,31 @@ func (s *FakeTeamService) GetByName(ctx context.Context, name string) (domain.Te
 	return domain.Team{Name: name, Members: []domain.TeamMember{}}, nil
 }
 
+func (s *FakeTeamService) UploadCodeOwners(ctx context.Context, teamName string, content string) ([]domain.CodeOwnerRule, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.createCalls[teamName] == 0 {
+		return nil, validateError.TeamNotFound
+	}
+	return services.ParseCodeOwners(content)
+}
+
+func (s *FakeTeamService) GetCodeOwners(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.createCalls[teamName] == 0 {
+		return nil, validateError.TeamNotFound
+	}
+	return []domain.CodeOwnerRule{}, nil
+}
+
 type FakeUserService struct {
 	registeredUsers map[string]domain.User
-	lock            sync.Mutex
+	pendingTopUps   []domain.TopUp
+	topUpErr        error
+	// failWith — ошибка, которую возвращают SetMaxOpenReviews и SetSchedule
+	failWith error
+	lock     sync.Mutex
 }
 
 func NewFakeUserService() *FakeUserService {
@@ -50,14 +91,53 @@ func NewFakeUserService() *FakeUserService {
 
 var _ services.UserSer = (*FakeUserService)(nil)
 
-func (s *FakeUserService) SetActive(ctx context.Context, id string, isActive bool) (domain.User, error) {
+func (s *FakeUserService) SetActive(ctx context.Context, id string, isActive bool) (domain.User, []domain.TopUp, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	user, ok := s.registeredUsers[id]
 	if !ok {
-		return domain.User{}, errors.New("user not found")
+		return domain.User{}, nil, validateError.UserNotFound
 	}
 	user.IsActive = isActive
+	s.registeredUsers[id] = user
+	var topUps []domain.TopUp
+	if isActive {
+		if s.topUpErr != nil {
+			return user, nil, fmt.Errorf("%w: %w", validateError.TopUpFailed, s.topUpErr)
+		}
+		topUps = s.pendingTopUps
+	}
+	return user, topUps, nil
+}
+
+func (s *FakeUserService) SetMaxOpenReviews(ctx context.Context, capacity domain.UserCapacity) (domain.User, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failWith != nil {
+		return domain.User{}, s.failWith
+	}
+	user, ok := s.registeredUsers[capacity.Id]
+	if !ok {
+		return domain.User{}, validateError.UserNotFound
+	}
+	user.MaxOpenReviews = capacity.MaxOpenReviews
+	return user, nil
+}
+
+func (s *FakeUserService) SetSchedule(ctx context.Context, id string, schedule domain.WorkSchedule) (domain.User, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failWith != nil {
+		return domain.User{}, s.failWith
+	}
+	user, ok := s.registeredUsers[id]
+	if !ok {
+		return domain.User{}, validateError.UserNotFound
+	}
+	if (schedule.WorkStart == nil) != (schedule.WorkEnd == nil) {
+		return domain.User{}, validateError.InvalidSchedule
+	}
+	user.Schedule = schedule
 	return user, nil
 }
 
@@ -74,8 +154,19 @@ func (s *FakeUserService) GetReview(ctx context.Context, userId string) (domain.
 type FakePRService struct {
 	createCalls map[string]int
 	createdPRs  map[string]domain.PullRequestRead
-	lock        sync.Mutex
-	users       *FakeUserService
+	declines    []domain.ReviewDecline
+	decisions   []domain.ReviewDecision
+	// graph — стек, который возвращает GetDependencyGraph
+	graph     domain.PRDependencyGraph
+	revisions []domain.Revision
+	// events — лента, которую возвращает GetTimeline
+	events []domain.PREvent
+	// stale — решения, которые PushRevision вернёт устаревшими
+	stale []domain.ReviewDecision
+	// failWith — ошибка, которую возвращают Create, Update, Merge, PushRevision и смена статуса; так проверяется, как обработчик отображает ошибки сервиса
+	failWith error
+	lock     sync.Mutex
+	users    *FakeUserService
 }
 
 func NewFakePRServiceWithUsers(users *FakeUserService) *FakePRService {
@@ -103,13 +194,40 @@ func (s *FakePRService) Create(ctx context.Context, createPr domain.PullRequestC
 			return domain.PullRequestRead{}, errors.New("pr not found")
 		}
 	}
+	if s.failWith != nil {
+		return domain.PullRequestRead{}, s.failWith
+	}
 	pr := domain.PullRequestRead{
 		Id: createPr.Id, Name: createPr.Name, AuthorId: createPr.AuthorId, Status: domain.StatusOpen, AssignReviewerIds: []string{"rev1", "rev2"},
 	}
+	pr.Labels = domain.NormalizeLabels(createPr.Labels)
+	pr.Priority = createPr.Priority
+	if pr.Priority == "" {
+		pr.Priority = domain.PriorityNormal
+	}
+	if createPr.Draft {
+		pr.Status = domain.StatusDraft
+		pr.AssignReviewerIds = []string{}
+	}
 	s.createdPRs[createPr.Id] = pr
 	return pr, nil
 }
 
+func (s *FakePRService) GetTimeline(ctx context.Context, prId string) ([]domain.PREvent, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.createdPRs[prId]; !ok {
+		return nil, validateError.ErrPrNotExist
+	}
+	events := make([]domain.PREvent, 0)
+	for _, e := range s.events {
+		if e.PullRequestId == prId {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
 func (s *FakePRService) Merge(ctx context.Context, prMerger domain.PRMerge) (domain.PRMergeRead, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -117,8 +235,16 @@ func (s *FakePRService) Merge(ctx context.Context, prMerger domain.PRMerge) (dom
 	if !ok {
 		return domain.PRMergeRead{}, errors.New("pr not found")
 	}
+	if s.failWith != nil {
+		return domain.PRMergeRead{}, s.failWith
+	}
 	pr.Status = domain.StatusMerged
-	return domain.PRMergeRead{Id: pr.Id, Name: pr.Name, AuthorId: pr.AuthorId, Status: pr.Status, AssignReviewerIds: pr.AssignReviewerIds}, nil
+	s.createdPRs[pr.Id] = pr
+	merged := domain.PRMergeRead{Id: pr.Id, Name: pr.Name, AuthorId: pr.AuthorId, Status: pr.Status, Labels: pr.Labels, Priority: pr.Priority, AssignReviewerIds: pr.AssignReviewerIds}
+	if prMerger.Force {
+		merged.ForcedBy = prMerger.UserId
+	}
+	return merged, nil
 }
 
 func (s *FakePRService) Reassign(ctx context.Context, pr domain.PRReassign) (domain.PrReassignRead, error) {
@@ -132,6 +258,361 @@ func (s *FakePRService) Reassign(ctx context.Context, pr domain.PRReassign) (dom
 		return domain.PrReassignRead{}, errors.New("no reviewers")
 	}
 	replacedId := origin.AssignReviewerIds[0]
-	origin.AssignReviewerIds[0] = "new_user"
-	return domain.PrReassignRead{PullRequest: origin, ReplacedId: replacedId}, nil
+	newUserId := "new_user"
+	if pr.NewUserId != "" {
+		if slices.Contains(origin.AssignReviewerIds, pr.NewUserId) {
+			return domain.PrReassignRead{}, validateError.ReviewerAlreadyAssigned
+		}
+		newUserId = pr.NewUserId
+	}
+	origin.AssignReviewerIds[0] = newUserId
+	return domain.PrReassignRead{PullRequest: origin, ReplacedId: replacedId, Requested: pr.NewUserId != ""}, nil
+}
+
+func (s *FakePRService) AddReviewer(ctx context.Context, change domain.PRReviewerChange) (domain.PullRequestRead, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pr, ok := s.createdPRs[change.Id]
+	if !ok {
+		return domain.PullRequestRead{}, validateError.ErrPrNotExist
+	}
+	if pr.Status == domain.StatusMerged {
+		return domain.PullRequestRead{}, validateError.PrMergedExist
+	}
+	if change.UserId == pr.AuthorId {
+		return domain.PullRequestRead{}, validateError.ReviewerIsAuthor
+	}
+	if slices.Contains(pr.AssignReviewerIds, change.UserId) {
+		return domain.PullRequestRead{}, validateError.ReviewerAlreadyAssigned
+	}
+	pr.AssignReviewerIds = append(slices.Clone(pr.AssignReviewerIds), change.UserId)
+	s.createdPRs[pr.Id] = pr
+	return pr, nil
+}
+
+func (s *FakePRService) RemoveReviewer(ctx context.Context, change domain.PRReviewerChange) (domain.PullRequestRead, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pr, ok := s.createdPRs[change.Id]
+	if !ok {
+		return domain.PullRequestRead{}, validateError.ErrPrNotExist
+	}
+	if pr.Status == domain.StatusMerged {
+		return domain.PullRequestRead{}, validateError.PrMergedExist
+	}
+	idx := slices.Index(pr.AssignReviewerIds, change.UserId)
+	if idx < 0 {
+		return domain.PullRequestRead{}, validateError.UserNotAssignReviewer
+	}
+	pr.AssignReviewerIds = slices.Delete(slices.Clone(pr.AssignReviewerIds), idx, idx+1)
+	s.createdPRs[pr.Id] = pr
+	return pr, nil
+}
+
+func (s *FakePRService) Decline(ctx context.Context, decline domain.PRDecline) (domain.PrReassignRead, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pr, ok := s.createdPRs[decline.Id]
+	if !ok {
+		return domain.PrReassignRead{}, validateError.ErrPrNotExist
+	}
+	idx := slices.Index(pr.AssignReviewerIds, decline.UserId)
+	if idx < 0 {
+		return domain.PrReassignRead{}, validateError.UserNotAssignReviewer
+	}
+	s.declines = append(s.declines, domain.ReviewDecline{PullRequestId: pr.Id, ReviewerId: decline.UserId, Reason: decline.Reason, Comment: decline.Comment})
+	pr.AssignReviewerIds = slices.Clone(pr.AssignReviewerIds)
+	pr.AssignReviewerIds[idx] = "new_user"
+	s.createdPRs[pr.Id] = pr
+	return domain.PrReassignRead{PullRequest: pr, ReplacedId: "new_user"}, nil
+}
+
+func (s *FakePRService) GetDeclinesByUserId(ctx context.Context, userId string) ([]domain.ReviewDecline, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	declines := make([]domain.ReviewDecline, 0)
+	for _, d := range s.declines {
+		if d.ReviewerId == userId {
+			declines = append(declines, d)
+		}
+	}
+	return declines, nil
+}
+
+func (s *FakePRService) GetDeclinesByTeamName(ctx context.Context, teamName string) ([]domain.ReviewDecline, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	declines := make([]domain.ReviewDecline, 0)
+	for _, d := range s.declines {
+		if d.TeamName == teamName {
+			declines = append(declines, d)
+		}
+	}
+	return declines, nil
+}
+
+func (s *FakePRService) SubmitReview(ctx context.Context, decision domain.ReviewDecision) (domain.ReviewDecision, domain.PullRequestRead, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pr, ok := s.createdPRs[decision.PullRequestId]
+	if !ok {
+		return domain.ReviewDecision{}, domain.PullRequestRead{}, validateError.ErrPrNotExist
+	}
+	if pr.Status == domain.StatusMerged {
+		return domain.ReviewDecision{}, domain.PullRequestRead{}, validateError.PrMergedExist
+	}
+	if !slices.Contains(pr.AssignReviewerIds, decision.ReviewerId) {
+		return domain.ReviewDecision{}, domain.PullRequestRead{}, validateError.UserNotAssignReviewer
+	}
+	decision.Id = int64(len(s.decisions) + 1)
+	decision.SubmittedAt = time.Now()
+	s.decisions = append(s.decisions, decision)
+
+	pr.Reviewers = s.reviewersWithDecisions(pr)
+	return decision, pr, nil
+}
+
+// reviewersWithDecisions прикладывает к ревьюерам PR их последние решения.
+func (s *FakePRService) reviewersWithDecisions(pr domain.PullRequestRead) []domain.AssignedReviewer {
+	latest := make(map[string]domain.ReviewDecision)
+	for _, d := range s.decisions {
+		if d.PullRequestId == pr.Id {
+			latest[d.ReviewerId] = d
+		}
+	}
+	reviewers := make([]domain.AssignedReviewer, 0, len(pr.AssignReviewerIds))
+	for _, id := range pr.AssignReviewerIds {
+		reviewer := domain.AssignedReviewer{Id: id}
+		if d, ok := latest[id]; ok {
+			reviewer.Decision = &d
+		}
+		reviewers = append(reviewers, reviewer)
+	}
+	return reviewers
+}
+
+func (s *FakePRService) GetReviewHistory(ctx context.Context, prId string) ([]domain.ReviewDecision, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.createdPRs[prId]; !ok {
+		return nil, validateError.ErrPrNotExist
+	}
+	decisions := make([]domain.ReviewDecision, 0)
+	for _, d := range s.decisions {
+		if d.PullRequestId == prId {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions, nil
+}
+
+func (s *FakePRService) PushRevision(ctx context.Context, revision domain.Revision) (domain.RevisionPush, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pr, ok := s.createdPRs[revision.PullRequestId]
+	if !ok {
+		return domain.RevisionPush{}, validateError.ErrPrNotExist
+	}
+	if s.failWith != nil {
+		return domain.RevisionPush{}, s.failWith
+	}
+	revision.Id = int64(len(s.revisions) + 1)
+	s.revisions = append(s.revisions, revision)
+	return domain.RevisionPush{Revision: revision, Stale: s.stale, PullRequest: pr}, nil
+}
+
+func (s *FakePRService) GetRevisions(ctx context.Context, prId string) ([]domain.Revision, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.createdPRs[prId]; !ok {
+		return nil, validateError.ErrPrNotExist
+	}
+	revisions := make([]domain.Revision, 0)
+	for _, rev := range s.revisions {
+		if rev.PullRequestId == prId {
+			revisions = append(revisions, rev)
+		}
+	}
+	return revisions, nil
+}
+
+func (s *FakePRService) Update(ctx context.Context, update domain.PRUpdate) (domain.PullRequestRead, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failWith != nil {
+		return domain.PullRequestRead{}, s.failWith
+	}
+	pr, ok := s.createdPRs[update.Id]
+	if !ok {
+		return domain.PullRequestRead{}, validateError.ErrPrNotExist
+	}
+	if pr.Status == domain.StatusMerged {
+		return domain.PullRequestRead{}, validateError.PrMergedExist
+	}
+	if update.Name != nil {
+		pr.Name = *update.Name
+	}
+	if update.Description != nil {
+		pr.Description = *update.Description
+	}
+	if update.ExternalURL != nil {
+		pr.ExternalURL = *update.ExternalURL
+	}
+	if update.Labels != nil {
+		pr.Labels = domain.NormalizeLabels(*update.Labels)
+	}
+	if update.Priority != nil {
+		pr.Priority = *update.Priority
+	}
+	if update.AuthorId != nil {
+		pr.AuthorId = *update.AuthorId
+	}
+	s.createdPRs[pr.Id] = pr
+	return pr, nil
+}
+
+func (s *FakePRService) GetDependencyGraph(ctx context.Context, prId string) (domain.PRDependencyGraph, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.createdPRs[prId]; !ok {
+		return domain.PRDependencyGraph{}, validateError.ErrPrNotExist
+	}
+	graph := s.graph
+	graph.Id = prId
+	return graph, nil
+}
+
+func (s *FakePRService) Ready(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error) {
+	return s.setStatus(change.Id, domain.StatusOpen)
+}
+
+func (s *FakePRService) Close(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error) {
+	return s.setStatus(change.Id, domain.StatusClosed)
+}
+
+func (s *FakePRService) Reopen(ctx context.Context, change domain.PRStatusChange) (domain.PullRequestRead, error) {
+	return s.setStatus(change.Id, domain.StatusOpen)
+}
+
+func (s *FakePRService) setStatus(prId string, status domain.PullRequestStatus) (domain.PullRequestRead, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pr, ok := s.createdPRs[prId]
+	if !ok {
+		return domain.PullRequestRead{}, validateError.ErrPrNotExist
+	}
+	if s.failWith != nil {
+		return domain.PullRequestRead{}, s.failWith
+	}
+	pr.Status = status
+	s.createdPRs[prId] = pr
+	return pr, nil
+}
+
+func (s *FakePRService) PreviewReviewers(ctx context.Context, createPr domain.PullRequestCreate) (domain.ReviewerPreview, error) {
+	s.users.lock.Lock()
+	defer s.users.lock.Unlock()
+	if _, ok := s.users.registeredUsers[createPr.AuthorId]; !ok {
+		return domain.ReviewerPreview{}, validateError.UserNotFound
+	}
+	return domain.ReviewerPreview{
+		Candidates:      []domain.ReviewerCandidate{{Id: "rev1"}, {Id: "rev2"}},
+		Reviewers:       []domain.AssignedReviewer{{Id: "rev1"}, {Id: "rev2"}},
+		Excluded:        []domain.ExcludedMember{{UserId: createPr.AuthorId, Reason: domain.ExclusionAuthor}},
+		MinReviewersMet: true,
+	}, nil
+}
+
+type FakeReviewerRuleService struct {
+	rules map[[2]string]domain.ReviewerRule
+	lock  sync.Mutex
+}
+
+func NewFakeReviewerRuleService() *FakeReviewerRuleService {
+	return &FakeReviewerRuleService{rules: make(map[[2]string]domain.ReviewerRule)}
+}
+
+var _ services.ReviewerRuleSer = (*FakeReviewerRuleService)(nil)
+
+func (s *FakeReviewerRuleService) Save(ctx context.Context, rule domain.ReviewerRule) (domain.ReviewerRule, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if rule.ReviewerId == rule.AuthorId {
+		return domain.ReviewerRule{}, validateError.InvalidReviewerRule
+	}
+	s.rules[[2]string{rule.ReviewerId, rule.AuthorId}] = rule
+	return rule, nil
+}
+
+func (s *FakeReviewerRuleService) Delete(ctx context.Context, rule domain.ReviewerRule) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := [2]string{rule.ReviewerId, rule.AuthorId}
+	if _, ok := s.rules[key]; !ok {
+		return validateError.ReviewerRuleNotFound
+	}
+	delete(s.rules, key)
+	return nil
+}
+
+func (s *FakeReviewerRuleService) GetByUserId(ctx context.Context, userId string) ([]domain.ReviewerRule, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	rules := make([]domain.ReviewerRule, 0)
+	for _, rule := range s.rules {
+		if rule.ReviewerId == userId || rule.AuthorId == userId {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+type FakeAbsenceService struct {
+	absences map[int64]domain.Absence
+	nextId   int64
+	topUpErr error
+	lock     sync.Mutex
+}
+
+func NewFakeAbsenceService() *FakeAbsenceService {
+	return &FakeAbsenceService{absences: make(map[int64]domain.Absence)}
+}
+
+var _ services.AbsenceSer = (*FakeAbsenceService)(nil)
+
+func (s *FakeAbsenceService) Create(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return domain.Absence{}, validateError.InvalidAbsence
+	}
+	s.nextId++
+	absence.Id = s.nextId
+	s.absences[absence.Id] = absence
+	return absence, nil
+}
+
+func (s *FakeAbsenceService) Delete(ctx context.Context, id int64) ([]domain.TopUp, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.absences[id]; !ok {
+		return nil, validateError.AbsenceNotFound
+	}
+	delete(s.absences, id)
+	if s.topUpErr != nil {
+		return nil, fmt.Errorf("%w: %w", validateError.TopUpFailed, s.topUpErr)
+	}
+	return []domain.TopUp{}, nil
+}
+
+func (s *FakeAbsenceService) GetByUserId(ctx context.Context, userId string) ([]domain.Absence, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	absences := make([]domain.Absence, 0)
+	for _, a := range s.absences {
+		if a.UserId == userId {
+			absences = append(absences, a)
+		}
+	}
+	return absences, nil
 }
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortReviewQueue(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	prs := []domain.PullRequestReviewRead{
+		{Id: "normal-old", Priority: domain.PriorityNormal, CreatedAt: day},
+		{Id: "low", Priority: domain.PriorityLow, CreatedAt: day.Add(-48 * time.Hour)},
+		{Id: "urgent-new", Priority: domain.PriorityUrgent, CreatedAt: day.Add(2 * time.Hour)},
+		{Id: "normal-older", Priority: domain.PriorityNormal, CreatedAt: day.Add(-time.Hour)},
+		{Id: "urgent-old", Priority: domain.PriorityUrgent, CreatedAt: day},
+		{Id: "high", Priority: domain.PriorityHigh, CreatedAt: day.Add(5 * time.Hour)},
+	}
+
+	domain.SortReviewQueue(prs)
+
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.Id)
+	}
+	assert.Equal(t, []string{"urgent-old", "urgent-new", "high", "normal-older", "normal-old", "low"}, ids)
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	assert.Equal(t, []string{"api", "db"}, domain.NormalizeLabels([]string{" api", "", "db", "api "}))
+	assert.Equal(t, []string{}, domain.NormalizeLabels(nil))
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRService_Timeline(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(fixedNow)
+	seedDevTeam(store, domain.Team{})
+	svc := newMemoryPRService(store)
+
+	_, err := svc.Create(ctx, domain.PullRequestCreate{Id: testPRID, Name: testPRName, AuthorId: testAuthorID})
+	require.NoError(t, err)
+	_, err = svc.Reassign(ctx, domain.PRReassign{Id: testPRID, OldUserId: "u2"})
+	require.NoError(t, err)
+	_, err = svc.Merge(ctx, domain.PRMerge{Id: testPRID})
+	require.NoError(t, err)
+	// повторный merge идемпотентен и не дописывает событие
+	_, err = svc.Merge(ctx, domain.PRMerge{Id: testPRID})
+	require.NoError(t, err)
+
+	events, err := svc.GetTimeline(ctx, testPRID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PREventKind{
+		domain.EventCreated,
+		domain.EventReviewerAssigned,
+		domain.EventReviewerAssigned,
+		domain.EventReassigned,
+		domain.EventMerged,
+	}, kindsOf(events))
+
+	assert.Equal(t, testAuthorID, events[0].ActorId)
+	assert.Equal(t, "u2", events[1].ToUserId)
+	assert.Equal(t, "u3", events[2].ToUserId)
+	assert.Equal(t, "u2", events[3].FromUserId)
+	assert.Equal(t, "u4", events[3].ToUserId)
+	assert.Equal(t, fixedNow, events[4].CreatedAt)
+
+	_, err = svc.GetTimeline(ctx, "missing")
+	assert.ErrorIs(t, err, validateError.ErrPrNotExist)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestStatusTransitions(t *testing.T) {
+	cases := []struct {
+		from, to domain.PullRequestStatus
+		allowed  bool
+	}{
+		{domain.StatusDraft, domain.StatusOpen, true},
+		{domain.StatusDraft, domain.StatusClosed, true},
+		{domain.StatusDraft, domain.StatusMerged, false},
+		{domain.StatusOpen, domain.StatusMerged, true},
+		{domain.StatusOpen, domain.StatusClosed, true},
+		{domain.StatusOpen, domain.StatusDraft, false},
+		{domain.StatusClosed, domain.StatusOpen, true},
+		{domain.StatusClosed, domain.StatusMerged, false},
+		{domain.StatusMerged, domain.StatusOpen, false},
+		{domain.StatusMerged, domain.StatusClosed, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.allowed, c.from.CanTransitionTo(c.to), "%s -> %s", c.from, c.to)
+	}
+}
+
+func TestPRService_StatusLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(fixedNow)
+	seedDevTeam(store, domain.Team{})
+	svc := newMemoryPRService(store)
+	change := domain.PRStatusChange{Id: testPRID}
+
+	_, err := svc.Create(ctx, domain.PullRequestCreate{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Draft: true, ReviewerIds: []string{"u2"}})
+	assert.ErrorIs(t, err, validateError.DraftWithReviewers)
+
+	draft, err := svc.Create(ctx, domain.PullRequestCreate{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Draft: true})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusDraft, draft.Status)
+	assert.Empty(t, draft.Reviewers)
+
+	_, err = svc.Reopen(ctx, change)
+	assert.ErrorIs(t, err, validateError.InvalidStatusTransition)
+
+	opened, err := svc.Ready(ctx, change)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOpen, opened.Status)
+	assert.Equal(t, []string{"u2", "u3"}, opened.AssignReviewerIds)
+	assert.Equal(t, 1, store.openReviews("u2"))
+
+	_, err = svc.Ready(ctx, change)
+	assert.ErrorIs(t, err, validateError.InvalidStatusTransition)
+
+	// Отказавшийся ревьюер не возвращается после переоткрытия
+	_, err = svc.Decline(ctx, domain.PRDecline{Id: testPRID, UserId: "u2", Reason: domain.DeclineOverloaded})
+	require.NoError(t, err)
+
+	closed, err := svc.Close(ctx, change)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusClosed, closed.Status)
+	assert.Empty(t, closed.AssignReviewerIds)
+	reviewers, err := store.Repos().PR.GetReviewersById(ctx, testPRID)
+	require.NoError(t, err)
+	assert.Empty(t, reviewers)
+	assert.Equal(t, 0, store.openReviews("u3"))
+
+	_, err = svc.Close(ctx, change)
+	assert.ErrorIs(t, err, validateError.InvalidStatusTransition)
+
+	reopened, err := svc.Reopen(ctx, change)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOpen, reopened.Status)
+	assert.Equal(t, []string{"u3", "u4"}, reopened.AssignReviewerIds)
+
+	assert.Equal(t, []domain.PREventKind{
+		domain.EventCreated, domain.EventReady, domain.EventReviewerAssigned, domain.EventReviewerAssigned,
+		domain.EventReviewDeclined, domain.EventReassigned,
+		domain.EventReviewerRemoved, domain.EventReviewerRemoved, domain.EventClosed,
+		domain.EventReopened, domain.EventReviewerAssigned, domain.EventReviewerAssigned,
+	}, kindsOf(store.Events(testPRID)))
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTransferStore заводит команды dev и qa и открытый PR u1 с ревьюерами из обеих команд.
+func newTransferStore(devFallbacks, qaFallbacks []string, reviewers ...domain.AssignedReviewer) *MemoryStore {
+	store := NewMemoryStore(fixedNow)
+	seedDevTeam(store, domain.Team{FallbackTeams: devFallbacks})
+
+	qa := MakeTestTeam(testTeamNameQA, []string{"q1", "q2", "q3"})
+	qa.ReviewersCount = domain.DefaultReviewersCount
+	qa.CapacityPolicy = domain.CapacityOverAssign
+	qa.FallbackTeams = qaFallbacks
+	store.AddTeam(qa)
+
+	store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusOpen}, reviewers...)
+	return store
+}
+
+func reviewersOf(pr domain.PullRequestRead) map[string]bool {
+	external := make(map[string]bool, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		external[r.Id] = r.External
+	}
+	return external
+}
+
+func TestPRService_Update(t *testing.T) {
+	ctx := context.Background()
+	transferTo := func(authorId string) domain.PRUpdate {
+		return domain.PRUpdate{Id: testPRID, AuthorId: &authorId}
+	}
+
+	t.Run("cross-team transfer recomputes external flags", func(t *testing.T) {
+		store := newTransferStore([]string{testTeamNameQA}, []string{testTeamDev},
+			domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "q2", External: true})
+		svc := newMemoryPRService(store)
+
+		pr, err := svc.Update(ctx, transferTo("q1"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "q1", pr.AuthorId)
+		assert.Equal(t, map[string]bool{"u2": true, "q2": false}, reviewersOf(pr))
+		assert.Equal(t, []domain.PREventKind{domain.EventAuthorChanged}, kindsOf(store.Events(testPRID)))
+	})
+
+	t.Run("reviewer outside the new author's teams is replaced", func(t *testing.T) {
+		store := newTransferStore([]string{testTeamNameQA}, nil,
+			domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "q2", External: true})
+		svc := newMemoryPRService(store)
+
+		pr, err := svc.Update(ctx, transferTo("q1"))
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]bool{"q2": false, "q3": false}, reviewersOf(pr))
+
+		events := store.Events(testPRID)
+		assert.Equal(t, []domain.PREventKind{domain.EventAuthorChanged, domain.EventReassigned}, kindsOf(events))
+		assert.Equal(t, "u2", events[1].FromUserId)
+		assert.Equal(t, "q3", events[1].ToUserId)
+	})
+
+	t.Run("new author who reviews the PR is replaced", func(t *testing.T) {
+		store := newTransferStore(nil, nil, domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "u3"})
+		svc := newMemoryPRService(store)
+
+		pr, err := svc.Update(ctx, transferTo("u2"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "u2", pr.AuthorId)
+		assert.Equal(t, []string{"u1", "u3"}, pr.AssignReviewerIds)
+	})
+
+	t.Run("reviewer excluded for the new author is replaced", func(t *testing.T) {
+		store := newTransferStore(nil, nil, domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "u3"})
+		store.rules = append(store.rules, domain.ReviewerRule{ReviewerId: "u3", AuthorId: "u4", Kind: domain.RuleExclude})
+		svc := newMemoryPRService(store)
+
+		pr, err := svc.Update(ctx, transferTo("u4"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"u1", "u2"}, pr.AssignReviewerIds)
+	})
+
+	t.Run("inactive new author is rejected and nothing changes", func(t *testing.T) {
+		store := newTransferStore(nil, nil, domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "u3"})
+		_, err := store.Repos().User.SetActiveById(ctx, "u4", false)
+		require.NoError(t, err)
+		svc := newMemoryPRService(store)
+
+		_, err = svc.Update(ctx, transferTo("u4"))
+		assert.ErrorIs(t, err, validateError.AuthorInactive)
+
+		pr, err := store.Repos().PR.GetById(ctx, testPRID)
+		require.NoError(t, err)
+		assert.Equal(t, testAuthorID, pr.AuthorId)
+		assert.Empty(t, store.Events(testPRID))
+	})
+
+	t.Run("merged PR cannot be updated", func(t *testing.T) {
+		store := newTransferStore(nil, nil)
+		store.prs[testPRID] = domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusMerged}
+		svc := newMemoryPRService(store)
+
+		_, err := svc.Update(ctx, transferTo("u2"))
+		assert.ErrorIs(t, err, validateError.PrMergedExist)
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -163,6 +165,44 @@ func TestPullRequestHandler_MergePR(t *testing.T) {
 		assert.Contains(t, bodyStr, "pr not found",
 			"expected error about PR not found, got: %s", w.Body.String())
 	})
+
+	t.Run("renders merge policy errors and forced merges", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		merge := func(payload map[string]interface{}) *httptest.ResponseRecorder {
+			body, err := json.Marshal(payload)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		prSvc.failWith = &validateError.MergeBlockedError{Unmet: []domain.MergeCondition{{Kind: domain.ConditionApprovals, Required: 1, Actual: 0}}}
+		w := merge(map[string]interface{}{"pull_request_id": testPRID})
+		require.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), `"condition":"approvals"`)
+
+		prSvc.failWith = validateError.ForceMergeNotAllowed
+		w = merge(map[string]interface{}{"pull_request_id": testPRID, "force": true, "user_id": "rev1"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		prSvc.failWith = nil
+		w = merge(map[string]interface{}{"pull_request_id": testPRID, "force": true})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = merge(map[string]interface{}{"pull_request_id": testPRID, "force": true, "user_id": "admin"})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"forced_by":"admin"`)
+	})
 }
 
 func TestPullRequestHandler_ReassignPR(t *testing.T) {
@@ -245,4 +285,629 @@ func TestPullRequestHandler_ReassignPR(t *testing.T) {
 		assert.Contains(t, bodyStr, "no reviewers",
 			"expected error about no reviewers, got: %s", w.Body.String())
 	})
+
+	t.Run("reassigns to requested reviewer", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"pull_request_id": testPRID,
+			"old_user_id":     "rev1",
+			"new_user_id":     "rev3",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"requested":true`)
+		assert.Contains(t, w.Body.String(), `"rev3"`)
+	})
+
+	t.Run("rejects requested reviewer already assigned", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"pull_request_id": testPRID,
+			"old_user_id":     "rev1",
+			"new_user_id":     "rev2",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestPullRequestHandler_PreviewReviewers(t *testing.T) {
+	t.Run("returns preview without creating pull request", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		userSvc.registeredUsers[testAuthorID] = MakeTestUser(testAuthorID, testAuthorName, testTeamDev, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"pull_request_id":   testPRID,
+			"pull_request_name": testPRName,
+			"author_id":         testAuthorID,
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/previewReviewers", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"reason":"author"`)
+		assert.Empty(t, prSvc.createdPRs)
+	})
+
+	t.Run("returns 404 when author does not exist", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"pull_request_id":   testPRID,
+			"pull_request_name": testPRName,
+			"author_id":         "nonexistent_user",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/previewReviewers", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPullRequestHandler_AddRemoveReviewer(t *testing.T) {
+	t.Run("adds and removes reviewer explicitly", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		body, err := json.Marshal(map[string]interface{}{"pull_request_id": testPRID, "user_id": "rev3"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"rev3"`)
+
+		body, err = json.Marshal(map[string]interface{}{"pull_request_id": testPRID, "user_id": "rev1"})
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodPost, "/pullRequest/removeReviewer", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), `"rev1"`)
+	})
+
+	t.Run("rejects author as reviewer", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		body, err := json.Marshal(map[string]interface{}{"pull_request_id": testPRID, "user_id": testAuthorID})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects changes on merged PR", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		pr := MakeTestPR(testPRID, testPRName, testAuthorID, true)
+		pr.Status = domain.StatusMerged
+		prSvc.createdPRs[testPRID] = pr
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		body, err := json.Marshal(map[string]interface{}{"pull_request_id": testPRID, "user_id": "rev1"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/removeReviewer", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestPullRequestHandler_DeclineReview(t *testing.T) {
+	t.Run("declines review and records reason", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		body, err := json.Marshal(map[string]interface{}{
+			"pull_request_id": testPRID,
+			"user_id":         "rev1",
+			"reason":          "no_context",
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/decline", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"replaced_by":"new_user"`)
+
+		getReq := httptest.NewRequest(http.MethodGet, "/pullRequest/declines/user/rev1", nil)
+		getW := httptest.NewRecorder()
+		router.ServeHTTP(getW, getReq)
+
+		assert.Equal(t, http.StatusOK, getW.Code)
+		assert.Contains(t, getW.Body.String(), `"reason":"no_context"`)
+	})
+
+	t.Run("rejects unknown reason", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		body, err := json.Marshal(map[string]interface{}{
+			"pull_request_id": testPRID,
+			"user_id":         "rev1",
+			"reason":          "bored",
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/decline", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPullRequestHandler_SubmitReview(t *testing.T) {
+	t.Run("latest decision replaces previous and history is kept", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		var w *httptest.ResponseRecorder
+		for _, decision := range []string{"CHANGES_REQUESTED", "APPROVED"} {
+			body, err := json.Marshal(map[string]interface{}{
+				"pull_request_id": testPRID,
+				"user_id":         "rev1",
+				"decision":        decision,
+				"body":            "looks " + decision,
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/submitReview", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+		}
+
+		var resp struct {
+			PR struct {
+				Reviewers []struct {
+					Id       string `json:"user_id"`
+					Decision *struct {
+						Decision string `json:"decision"`
+					} `json:"decision"`
+				} `json:"reviewers"`
+			} `json:"pr"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.PR.Reviewers, 2)
+		require.NotNil(t, resp.PR.Reviewers[0].Decision)
+		assert.Equal(t, "APPROVED", resp.PR.Reviewers[0].Decision.Decision)
+		assert.Nil(t, resp.PR.Reviewers[1].Decision)
+
+		getReq := httptest.NewRequest(http.MethodGet, "/pullRequest/reviews/"+testPRID, nil)
+		getW := httptest.NewRecorder()
+		router.ServeHTTP(getW, getReq)
+
+		require.Equal(t, http.StatusOK, getW.Code)
+		assert.Contains(t, getW.Body.String(), `"decision":"CHANGES_REQUESTED"`)
+		assert.Contains(t, getW.Body.String(), `"decision":"APPROVED"`)
+	})
+
+	t.Run("rejects reviewer not assigned", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		body, err := json.Marshal(map[string]interface{}{
+			"pull_request_id": testPRID,
+			"user_id":         "stranger",
+			"decision":        "APPROVED",
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/submitReview", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPullRequestHandler_StatusLifecycle(t *testing.T) {
+	teamSvc := NewFakeTeamService()
+	userSvc := NewFakeUserService()
+	userSvc.registeredUsers[testAuthorID] = MakeTestUser(testAuthorID, "author", testTeamName, true)
+	prSvc := NewFakePRServiceWithUsers(userSvc)
+	router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+	post := func(path string, payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	pr := map[string]interface{}{"pull_request_id": testPRID}
+
+	w := post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   testPRID,
+		"pull_request_name": testPRName,
+		"author_id":         testAuthorID,
+		"draft":             true,
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"DRAFT"`)
+	assert.Contains(t, w.Body.String(), `"assign_reviewer":[]`)
+
+	w = post("/pullRequest/ready", pr)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"OPEN"`)
+
+	w = post("/pullRequest/close", pr)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"CLOSED"`)
+
+	w = post("/pullRequest/reopen", pr)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"OPEN"`)
+
+	prSvc.failWith = validateError.InvalidStatusTransition
+	for _, path := range []string{"/pullRequest/ready", "/pullRequest/close", "/pullRequest/reopen"} {
+		assert.Equal(t, http.StatusConflict, post(path, pr).Code, path)
+	}
+	prSvc.failWith = validateError.DraftWithReviewers
+	w = post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   testPRID2,
+		"pull_request_name": testPRName,
+		"author_id":         testAuthorID,
+		"draft":             true,
+		"reviewer_ids":      []string{"rev1"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	prSvc.failWith = nil
+	assert.Equal(t, http.StatusNotFound, post("/pullRequest/ready", map[string]interface{}{"pull_request_id": "missing"}).Code)
+}
+
+func TestPullRequestHandler_UpdatePR(t *testing.T) {
+	patch := func(router http.Handler, payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPatch, "/pullRequest/update", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("updates details and keeps omitted fields", func(t *testing.T) {
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+		router := SetupTestRouter(NewFakeTeamService(), userSvc, prSvc)
+
+		w := patch(router, map[string]interface{}{
+			"pull_request_id": testPRID,
+			"description":     "adds caching",
+			"external_url":    "https://git.example.com/repo/pull/1",
+		})
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"pull_request_name":"`+testPRName+`"`)
+		assert.Contains(t, w.Body.String(), `"description":"adds caching"`)
+		assert.Contains(t, w.Body.String(), `"external_url":"https://git.example.com/repo/pull/1"`)
+	})
+
+	t.Run("inactive new author is a bad request", func(t *testing.T) {
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		prSvc.failWith = validateError.AuthorInactive
+		router := SetupTestRouter(NewFakeTeamService(), userSvc, prSvc)
+
+		w := patch(router, map[string]interface{}{"pull_request_id": testPRID, "author_id": "rev1"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), validateError.AuthorInactive.Error())
+	})
+
+	t.Run("rejects invalid external url", func(t *testing.T) {
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(NewFakeTeamService(), userSvc, prSvc)
+
+		w := patch(router, map[string]interface{}{"pull_request_id": testPRID, "external_url": "not a url"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPullRequestHandler_LabelsAndPriority(t *testing.T) {
+	userSvc := NewFakeUserService()
+	userSvc.registeredUsers[testAuthorID] = MakeTestUser(testAuthorID, "author", testTeamName, true)
+	prSvc := NewFakePRServiceWithUsers(userSvc)
+	router := SetupTestRouter(NewFakeTeamService(), userSvc, prSvc)
+
+	send := func(method, path string, payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodPost, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   testPRID,
+		"pull_request_name": testPRName,
+		"author_id":         testAuthorID,
+		"labels":            []string{"backend", " backend ", "hotfix"},
+		"priority":          "urgent",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"labels":["backend","hotfix"]`)
+	assert.Contains(t, w.Body.String(), `"priority":"urgent"`)
+
+	w = send(http.MethodPatch, "/pullRequest/update", map[string]interface{}{
+		"pull_request_id": testPRID,
+		"labels":          []string{},
+		"priority":        "low",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"labels":[]`)
+	assert.Contains(t, w.Body.String(), `"priority":"low"`)
+
+	w = send(http.MethodPatch, "/pullRequest/update", map[string]interface{}{
+		"pull_request_id": testPRID,
+		"priority":        "asap",
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPullRequestHandler_StackedPRs(t *testing.T) {
+	userSvc := NewFakeUserService()
+	userSvc.registeredUsers[testAuthorID] = MakeTestUser(testAuthorID, "author", testTeamName, true)
+	prSvc := NewFakePRServiceWithUsers(userSvc)
+	router := SetupTestRouter(NewFakeTeamService(), userSvc, prSvc)
+
+	create := func(id string, parents ...string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(map[string]interface{}{
+			"pull_request_id":   id,
+			"pull_request_name": id,
+			"author_id":         testAuthorID,
+			"parent_ids":        parents,
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusCreated, create("middle", "base").Code)
+
+	prSvc.failWith = validateError.DependencyCycle
+	assert.Equal(t, http.StatusConflict, create("self", "self").Code)
+	prSvc.failWith = validateError.InvalidParent
+	assert.Equal(t, http.StatusBadRequest, create("orphan", "missing").Code)
+	prSvc.failWith = nil
+
+	prSvc.graph = domain.PRDependencyGraph{
+		Nodes: []domain.PRGraphNode{
+			{Id: "base", Name: "base", Status: domain.StatusOpen},
+			{Id: "middle", Name: "middle", Status: domain.StatusOpen},
+			{Id: "top", Name: "top", Status: domain.StatusOpen},
+		},
+		Edges: []domain.PRDependency{{ChildId: "middle", ParentId: "base"}, {ChildId: "top", ParentId: "middle"}},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/dependencies/middle", nil)
+	graphW := httptest.NewRecorder()
+	router.ServeHTTP(graphW, req)
+	require.Equal(t, http.StatusOK, graphW.Code)
+
+	var graph struct {
+		Nodes []struct {
+			Id        string   `json:"pull_request_id"`
+			ParentIds []string `json:"parent_ids"`
+		} `json:"nodes"`
+		BlockingChain []string `json:"blocking_chain"`
+	}
+	require.NoError(t, json.Unmarshal(graphW.Body.Bytes(), &graph))
+	require.Len(t, graph.Nodes, 3)
+	assert.Equal(t, "top", graph.Nodes[2].Id)
+	assert.Equal(t, []string{"middle"}, graph.Nodes[2].ParentIds)
+	assert.Equal(t, []string{"base"}, graph.BlockingChain)
+
+	req = httptest.NewRequest(http.MethodGet, "/pullRequest/dependencies/missing", nil)
+	graphW = httptest.NewRecorder()
+	router.ServeHTTP(graphW, req)
+	assert.Equal(t, http.StatusNotFound, graphW.Code)
+}
+
+func TestPullRequestHandler_Revisions(t *testing.T) {
+	userSvc := NewFakeUserService()
+	prSvc := NewFakePRServiceWithUsers(userSvc)
+	prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+	router := SetupTestRouter(NewFakeTeamService(), userSvc, prSvc)
+
+	push := func(sha string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(map[string]interface{}{"pull_request_id": testPRID, "commit_sha": sha})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/revision", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusCreated, push("a1b2c3d").Code)
+	assert.Equal(t, http.StatusBadRequest, push("not-a-sha").Code)
+
+	prSvc.failWith = validateError.RevisionExists
+	assert.Equal(t, http.StatusConflict, push("a1b2c3d").Code)
+	prSvc.failWith = validateError.PrNotOpen
+	assert.Equal(t, http.StatusConflict, push("a1b2c3d").Code)
+	prSvc.failWith = nil
+
+	revisionId := int64(1)
+	prSvc.stale = []domain.ReviewDecision{{Id: 7, PullRequestId: testPRID, ReviewerId: "rev1", Decision: domain.DecisionApproved, RevisionId: &revisionId, CommitSHA: "a1b2c3d", Stale: true}}
+	w := push("e4f5a6b")
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp struct {
+		Stale []struct {
+			ReviewerId string `json:"user_id"`
+			CommitSHA  string `json:"commit_sha"`
+			Stale      bool   `json:"stale"`
+		} `json:"stale_reviews"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Stale, 1)
+	assert.Equal(t, "rev1", resp.Stale[0].ReviewerId)
+	assert.Equal(t, "a1b2c3d", resp.Stale[0].CommitSHA)
+	assert.True(t, resp.Stale[0].Stale)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/revisions/"+testPRID, nil)
+	getW := httptest.NewRecorder()
+	router.ServeHTTP(getW, req)
+	require.Equal(t, http.StatusOK, getW.Code)
+	assert.Contains(t, getW.Body.String(), `"commit_sha":"e4f5a6b"`)
+}
+
+func TestPullRequestHandler_Timeline(t *testing.T) {
+	userSvc := NewFakeUserService()
+	prSvc := NewFakePRServiceWithUsers(userSvc)
+	prSvc.createdPRs[testPRID] = MakeTestPR(testPRID, testPRName, testAuthorID, true)
+	prSvc.events = []domain.PREvent{
+		{Id: 1, PullRequestId: testPRID, Kind: domain.EventCreated, ActorId: testAuthorID, CreatedAt: fixedNow},
+		{Id: 2, PullRequestId: testPRID, Kind: domain.EventReassigned, FromUserId: "rev1", ToUserId: "rev3", CreatedAt: fixedNow},
+	}
+	router := SetupTestRouter(NewFakeTeamService(), userSvc, prSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/timeline/"+testPRID, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Events []struct {
+			Kind       string `json:"kind"`
+			ActorId    string `json:"actor_id"`
+			FromUserId string `json:"from_user_id"`
+			ToUserId   string `json:"to_user_id"`
+		} `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Events, 2)
+	assert.Equal(t, "created", resp.Events[0].Kind)
+	assert.Equal(t, testAuthorID, resp.Events[0].ActorId)
+	assert.Equal(t, "reassigned", resp.Events[1].Kind)
+	assert.Equal(t, "rev1", resp.Events[1].FromUserId)
+	assert.Equal(t, "rev3", resp.Events[1].ToUserId)
+
+	req = httptest.NewRequest(http.MethodGet, "/pullRequest/timeline/missing", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
 }
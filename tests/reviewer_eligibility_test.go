@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcludesReviewer(t *testing.T) {
+	rules := []domain.ReviewerRule{
+		{ReviewerId: "rev1", AuthorId: "author", Kind: domain.RuleExclude},
+		{ReviewerId: "rev2", AuthorId: "author", Kind: domain.RulePrefer},
+	}
+
+	assert.True(t, domain.ExcludesReviewer(rules, "rev1", "author"))
+	assert.False(t, domain.ExcludesReviewer(rules, "rev2", "author"), "prefer rule does not exclude")
+	assert.False(t, domain.ExcludesReviewer(rules, "rev1", "other"), "rule is per author")
+}
+
+func TestAbsentAt(t *testing.T) {
+	start := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	absences := []domain.Absence{{UserId: "rev1", StartsAt: start, EndsAt: start.Add(48 * time.Hour)}}
+
+	assert.True(t, domain.AbsentAt(absences, start))
+	assert.True(t, domain.AbsentAt(absences, start.Add(24*time.Hour)))
+	assert.False(t, domain.AbsentAt(absences, start.Add(48*time.Hour)), "end is exclusive")
+	assert.False(t, domain.AbsentAt(absences, start.Add(-time.Minute)))
+	assert.False(t, domain.AbsentAt(nil, start))
+}
+
+func TestTeam_ReassessReviewers(t *testing.T) {
+	t.Run("cross-team transfer marks old team reviewers external or invalid", func(t *testing.T) {
+		// PR переходит от автора из backend к автору из frontend, у которой резервная команда — backend
+		frontend := domain.Team{Name: "frontend", FallbackTeams: []string{"backend"}}
+		reviewers := []domain.AssignedReviewer{{Id: "back1"}, {Id: "front1"}, {Id: "ops1"}, {Id: "new_author"}}
+		teamOf := map[string]string{"back1": "backend", "front1": "frontend", "ops1": "ops", "new_author": "frontend"}
+
+		reassessed, invalid := frontend.ReassessReviewers("new_author", reviewers, teamOf, nil)
+
+		assert.Equal(t, []domain.AssignedReviewer{
+			{Id: "back1", External: true},
+			{Id: "front1"},
+			{Id: "ops1", External: true},
+			{Id: "new_author"},
+		}, reassessed)
+		assert.Equal(t, []string{"ops1", "new_author"}, invalid)
+	})
+
+	t.Run("reviewer excluded for the new author is invalid", func(t *testing.T) {
+		team := domain.Team{Name: "backend"}
+		reviewers := []domain.AssignedReviewer{{Id: "rev1"}, {Id: "rev2"}}
+		teamOf := map[string]string{"rev1": "backend", "rev2": "backend"}
+		rules := []domain.ReviewerRule{{ReviewerId: "rev2", AuthorId: "author", Kind: domain.RuleExclude}}
+
+		_, invalid := team.ReassessReviewers("author", reviewers, teamOf, rules)
+		assert.Equal(t, []string{"rev2"}, invalid)
+	})
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exclusionReasons(excluded []domain.ExcludedMember) map[string]domain.ExclusionReason {
+	reasons := make(map[string]domain.ExclusionReason, len(excluded))
+	for _, e := range excluded {
+		reasons[e.UserId] = e.Reason
+	}
+	return reasons
+}
+
+func TestPRService_PreviewReviewers(t *testing.T) {
+	ctx := context.Background()
+	request := domain.PullRequestCreate{Id: testPRID, Name: testPRName, AuthorId: testAuthorID}
+
+	t.Run("absence is judged by the service clock", func(t *testing.T) {
+		store := NewMemoryStore(fixedNow)
+		seedDevTeam(store, domain.Team{})
+		absences := store.Repos().Absence
+		_, err := absences.Create(ctx, domain.Absence{UserId: "u2", StartsAt: fixedNow.Add(-time.Hour), EndsAt: fixedNow.Add(time.Hour)})
+		require.NoError(t, err)
+		_, err = absences.Create(ctx, domain.Absence{UserId: "u3", StartsAt: fixedNow.Add(-48 * time.Hour), EndsAt: fixedNow.Add(-time.Hour)})
+		require.NoError(t, err)
+		svc := newMemoryPRService(store)
+
+		preview, err := svc.PreviewReviewers(ctx, request)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"u3", "u4"}, domain.ReviewerIds(preview.Reviewers))
+		assert.Equal(t, domain.ExclusionAbsent, exclusionReasons(preview.Excluded)["u2"])
+		assert.NotContains(t, exclusionReasons(preview.Excluded), "u3")
+	})
+
+	t.Run("saturated team under reject policy previews no reviewers", func(t *testing.T) {
+		store := NewMemoryStore(fixedNow)
+		seedDevTeam(store, domain.Team{CapacityPolicy: domain.CapacityReject})
+		limit := 1
+		for _, id := range []string{"u2", "u3"} {
+			_, err := store.Repos().User.SetMaxOpenReviewsById(ctx, id, &limit)
+			require.NoError(t, err)
+		}
+		store.AddPR(domain.PullRequestRead{Id: testPRID2, Name: testPRName, AuthorId: "u4", Status: domain.StatusOpen},
+			domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "u3"})
+		svc := newMemoryPRService(store)
+
+		_, err := svc.Create(ctx, request)
+		require.ErrorIs(t, err, validateError.ReviewersAtCapacity)
+
+		preview, err := svc.PreviewReviewers(ctx, request)
+		require.NoError(t, err)
+
+		assert.Empty(t, preview.Reviewers)
+		assert.NotNil(t, preview.Reviewers)
+		assert.False(t, preview.MinReviewersMet)
+		reasons := exclusionReasons(preview.Excluded)
+		assert.Equal(t, domain.ExclusionAtCapacity, reasons["u2"])
+		assert.Equal(t, domain.ExclusionAtCapacity, reasons["u3"])
+		assert.Equal(t, domain.ExclusionNotSelected, reasons["u4"])
+	})
+}
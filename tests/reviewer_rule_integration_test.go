@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewerRuleHandler_SaveRule(t *testing.T) {
+	t.Run("successfully saves exclusion rule", func(t *testing.T) {
+		ruleSvc := NewFakeReviewerRuleService()
+		router := SetupReviewerRuleRouter(ruleSvc)
+
+		payload := map[string]interface{}{
+			"reviewer_id": testUserID1,
+			"author_id":   testUserID2,
+			"kind":        "exclude",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/reviewerRules/save", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		getReq := httptest.NewRequest(http.MethodGet, "/reviewerRules/get/"+testUserID2, nil)
+		getW := httptest.NewRecorder()
+		router.ServeHTTP(getW, getReq)
+
+		assert.Equal(t, http.StatusOK, getW.Code)
+		assert.Contains(t, getW.Body.String(), `"kind":"exclude"`)
+	})
+
+	t.Run("rejects unknown rule kind", func(t *testing.T) {
+		ruleSvc := NewFakeReviewerRuleService()
+		router := SetupReviewerRuleRouter(ruleSvc)
+
+		payload := map[string]interface{}{
+			"reviewer_id": testUserID1,
+			"author_id":   testUserID2,
+			"kind":        "maybe",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/reviewerRules/save", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects rule for the same user", func(t *testing.T) {
+		ruleSvc := NewFakeReviewerRuleService()
+		router := SetupReviewerRuleRouter(ruleSvc)
+
+		payload := map[string]interface{}{
+			"reviewer_id": testUserID1,
+			"author_id":   testUserID1,
+			"kind":        "prefer",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/reviewerRules/save", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestReviewerRuleHandler_DeleteRule(t *testing.T) {
+	t.Run("returns 404 for missing rule", func(t *testing.T) {
+		ruleSvc := NewFakeReviewerRuleService()
+		router := SetupReviewerRuleRouter(ruleSvc)
+
+		payload := map[string]interface{}{
+			"reviewer_id": testUserID1,
+			"author_id":   testUserID2,
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/reviewerRules/delete", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPRService_ReassignRequestedReviewer(t *testing.T) {
+	ctx := context.Background()
+	newStore := func() *MemoryStore {
+		store := NewMemoryStore(fixedNow)
+		seedDevTeam(store, domain.Team{})
+		store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusOpen},
+			domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "u3"})
+		return store
+	}
+	requestU4 := domain.PRReassign{Id: testPRID, OldUserId: "u2", NewUserId: "u4"}
+
+	t.Run("assigns requested reviewer", func(t *testing.T) {
+		svc := newMemoryPRService(newStore())
+
+		res, err := svc.Reassign(ctx, requestU4)
+		require.NoError(t, err)
+		assert.Equal(t, "u4", res.ReplacedId)
+		assert.Equal(t, []string{"u3", "u4"}, res.PullRequest.AssignReviewerIds)
+	})
+
+	t.Run("rejects reviewer excluded for the author", func(t *testing.T) {
+		store := newStore()
+		store.rules = append(store.rules, domain.ReviewerRule{ReviewerId: "u4", AuthorId: testAuthorID, Kind: domain.RuleExclude})
+		svc := newMemoryPRService(store)
+
+		_, err := svc.Reassign(ctx, requestU4)
+		assert.ErrorIs(t, err, validateError.ReviewerExcluded)
+
+		reviewers, err := store.Repos().PR.GetReviewersById(ctx, testPRID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.AssignedReviewer{{Id: "u2"}, {Id: "u3"}}, reviewers)
+	})
+
+	t.Run("rejects absent reviewer", func(t *testing.T) {
+		store := newStore()
+		store.absences = append(store.absences, domain.Absence{Id: 1, UserId: "u4", StartsAt: fixedNow.Add(-time.Hour), EndsAt: fixedNow.Add(time.Hour)})
+		svc := newMemoryPRService(store)
+
+		_, err := svc.Reassign(ctx, requestU4)
+		assert.ErrorIs(t, err, validateError.ReviewerAbsent)
+	})
+}
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/repositories"
+	"github.com/linspacestrom/InterShipAv/internal/services"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeCandidates(ids ...string) []domain.ReviewerCandidate {
+	candidates := make([]domain.ReviewerCandidate, 0, len(ids))
+	for _, id := range ids {
+		candidates = append(candidates, domain.ReviewerCandidate{Id: id, TeamName: testTeamDev})
+	}
+	return candidates
+}
+
+func TestSelectorRegistry(t *testing.T) {
+	t.Run("uses team strategy and falls back to default", func(t *testing.T) {
+		random := services.NewRandomSelector()
+		registry := services.NewSelectorRegistry(services.StrategyRandom, map[string]string{"qa": "custom"})
+		registry.Register(services.StrategyRandom, random)
+
+		_, err := registry.ForTeam("qa")
+		assert.ErrorIs(t, err, validateError.UnknownStrategy)
+
+		selector, err := registry.ForTeam(testTeamDev)
+		require.NoError(t, err)
+		assert.Equal(t, random, selector)
+	})
+
+	t.Run("validate reports unknown strategies", func(t *testing.T) {
+		registry := services.NewSelectorRegistry("missing", nil)
+		registry.Register(services.StrategyRandom, services.NewRandomSelector())
+
+		assert.ErrorIs(t, registry.Validate(), validateError.UnknownStrategy)
+	})
+}
+
+func TestRandomSelector(t *testing.T) {
+	t.Run("returns requested number of distinct candidates", func(t *testing.T) {
+		selector := services.NewRandomSelector()
+
+		ids, err := selector.Select(context.Background(), services.SelectRequest{
+			Candidates: makeCandidates("u2", "u3", "u4"),
+			Count:      2,
+		})
+		require.NoError(t, err)
+		require.Len(t, ids, 2)
+		assert.NotEqual(t, ids[0], ids[1])
+	})
+
+	t.Run("returns whole pool when it is smaller than count", func(t *testing.T) {
+		selector := services.NewRandomSelector()
+
+		ids, err := selector.Select(context.Background(), services.SelectRequest{
+			Candidates: makeCandidates("u2"),
+			Count:      2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, ids)
+	})
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	t.Run("prefers candidates with fewer open reviews", func(t *testing.T) {
+		selector := services.NewLeastLoadedSelector()
+		candidates := []domain.ReviewerCandidate{
+			{Id: "busy", OpenReviews: 10},
+			{Id: "free", OpenReviews: 0},
+			{Id: "medium", OpenReviews: 3},
+		}
+
+		ids, err := selector.Select(context.Background(), services.SelectRequest{Candidates: candidates, Count: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"free", "medium"}, ids)
+	})
+
+	t.Run("does not reorder caller candidates", func(t *testing.T) {
+		selector := services.NewLeastLoadedSelector()
+		candidates := []domain.ReviewerCandidate{{Id: "a", OpenReviews: 2}, {Id: "b", OpenReviews: 1}}
+
+		_, err := selector.Select(context.Background(), services.SelectRequest{Candidates: candidates, Count: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "a", candidates[0].Id)
+	})
+}
+
+type fakeRotationRepo struct {
+	repositories.TeamRepo
+	entries []domain.RotationEntry
+	cursor  int
+}
+
+func (r *fakeRotationRepo) LockRotation(ctx context.Context, name string) ([]domain.RotationEntry, int, error) {
+	return r.entries, r.cursor, nil
+}
+
+func (r *fakeRotationRepo) SetRotationCursor(ctx context.Context, name string, cursor int) error {
+	r.cursor = cursor
+	return nil
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	t.Run("hands out slots in rotation order and skips ineligible users", func(t *testing.T) {
+		repo := &fakeRotationRepo{entries: []domain.RotationEntry{
+			{UserId: "u1", Position: 1},
+			{UserId: "u2", Position: 2},
+			{UserId: "u3", Position: 3},
+			{UserId: "u4", Position: 4},
+		}}
+		selector := services.NewRoundRobinSelector(repo)
+
+		ids, err := selector.Select(context.Background(), services.SelectRequest{
+			TeamName:   testTeamDev,
+			Candidates: makeCandidates("u2", "u3", "u4"),
+			Count:      2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, ids)
+		assert.Equal(t, 3, repo.cursor)
+
+		ids, err = selector.Select(context.Background(), services.SelectRequest{
+			TeamName:   testTeamDev,
+			Candidates: makeCandidates("u2", "u3", "u4"),
+			Count:      2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u2"}, ids)
+		assert.Equal(t, 2, repo.cursor)
+	})
+}
+
+type fakeHistoryRepo struct {
+	repositories.PrRepo
+	counts map[string]int
+	since  time.Time
+}
+
+func (r *fakeHistoryRepo) GetRecentReviewCounts(ctx context.Context, authorId string, since time.Time) (map[string]int, error) {
+	r.since = since
+	return r.counts, nil
+}
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestKnowledgeSpreadingSelector(t *testing.T) {
+	t.Run("prefers reviewers who rarely reviewed the author", func(t *testing.T) {
+		repo := &fakeHistoryRepo{counts: map[string]int{"u1": 5, "u2": 0, "u3": 2}}
+		clock := fixedClock{now: fixedNow}
+		selector := services.NewKnowledgeSpreadingSelector(repo, clock, 7*24*time.Hour)
+
+		ids, err := selector.Select(context.Background(), services.SelectRequest{
+			TeamName:   testTeamDev,
+			AuthorId:   testAuthorID,
+			Candidates: makeCandidates("u1", "u2", "u3"),
+			Count:      2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, ids)
+		assert.Equal(t, fixedNow.Add(-7*24*time.Hour), repo.since)
+	})
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRevisionStore(policy domain.StaleReviewPolicy) *MemoryStore {
+	store := NewMemoryStore(fixedNow)
+	seedDevTeam(store, domain.Team{RequiredApprovals: 1, StaleReviewPolicy: policy})
+	store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: testAuthorID, Status: domain.StatusOpen},
+		domain.AssignedReviewer{Id: "u2"}, domain.AssignedReviewer{Id: "u3"})
+	return store
+}
+
+func TestPRService_PushRevision(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dismisses approvals from earlier revisions and blocks merge again", func(t *testing.T) {
+		store := newRevisionStore(domain.StaleDismissApprovals)
+		svc := newMemoryPRService(store)
+
+		first, err := svc.PushRevision(ctx, domain.Revision{PullRequestId: testPRID, CommitSHA: "a1b2c3d"})
+		require.NoError(t, err)
+		assert.Equal(t, fixedNow, first.Revision.PushedAt)
+		assert.Empty(t, first.Stale)
+
+		approval, _, err := svc.SubmitReview(ctx, domain.ReviewDecision{PullRequestId: testPRID, ReviewerId: "u2", Decision: domain.DecisionApproved})
+		require.NoError(t, err)
+		require.NotNil(t, approval.RevisionId)
+		assert.Equal(t, first.Revision.Id, *approval.RevisionId)
+		assert.Equal(t, "a1b2c3d", approval.CommitSHA)
+		_, _, err = svc.SubmitReview(ctx, domain.ReviewDecision{PullRequestId: testPRID, ReviewerId: "u3", Decision: domain.DecisionCommented})
+		require.NoError(t, err)
+
+		second, err := svc.PushRevision(ctx, domain.Revision{PullRequestId: testPRID, CommitSHA: "e4f5a6b"})
+		require.NoError(t, err)
+		require.Len(t, second.Stale, 1)
+		assert.Equal(t, approval.Id, second.Stale[0].Id)
+		assert.True(t, second.Stale[0].Stale)
+
+		require.Len(t, second.PullRequest.Reviewers, 2)
+		assert.True(t, second.PullRequest.Reviewers[0].Decision.Stale)
+		assert.False(t, second.PullRequest.Reviewers[1].Decision.Stale)
+
+		_, err = svc.Merge(ctx, domain.PRMerge{Id: testPRID})
+		assert.ErrorIs(t, err, validateError.MergeBlocked)
+
+		events := store.Events(testPRID)
+		assert.Equal(t, domain.EventRevisionPushed, events[len(events)-1].Kind)
+		assert.Equal(t, "e4f5a6b", events[len(events)-1].Details)
+	})
+
+	t.Run("keep policy leaves decisions in force", func(t *testing.T) {
+		store := newRevisionStore(domain.StaleKeep)
+		svc := newMemoryPRService(store)
+
+		_, _, err := svc.SubmitReview(ctx, domain.ReviewDecision{PullRequestId: testPRID, ReviewerId: "u2", Decision: domain.DecisionApproved})
+		require.NoError(t, err)
+
+		push, err := svc.PushRevision(ctx, domain.Revision{PullRequestId: testPRID, CommitSHA: "a1b2c3d"})
+		require.NoError(t, err)
+		assert.Empty(t, push.Stale)
+
+		_, err = svc.Merge(ctx, domain.PRMerge{Id: testPRID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a repeated commit without recording it", func(t *testing.T) {
+		store := newRevisionStore(domain.StaleDismissAll)
+		svc := newMemoryPRService(store)
+
+		_, err := svc.PushRevision(ctx, domain.Revision{PullRequestId: testPRID, CommitSHA: "a1b2c3d"})
+		require.NoError(t, err)
+		_, err = svc.PushRevision(ctx, domain.Revision{PullRequestId: testPRID, CommitSHA: "a1b2c3d"})
+		assert.ErrorIs(t, err, validateError.RevisionExists)
+
+		revisions, err := svc.GetRevisions(ctx, testPRID)
+		require.NoError(t, err)
+		assert.Len(t, revisions, 1)
+		assert.Len(t, store.Events(testPRID), 1)
+	})
+
+	t.Run("only open PRs accept revisions", func(t *testing.T) {
+		store := newRevisionStore(domain.StaleKeep)
+		svc := newMemoryPRService(store)
+		_, err := svc.Close(ctx, domain.PRStatusChange{Id: testPRID})
+		require.NoError(t, err)
+
+		_, err = svc.PushRevision(ctx, domain.Revision{PullRequestId: testPRID, CommitSHA: "a1b2c3d"})
+		assert.ErrorIs(t, err, validateError.PrNotOpen)
+	})
+}
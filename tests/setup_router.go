@@ -15,10 +15,14 @@ func SetupTestRouter(team services.TeamSer, user services.UserSer, pr services.P
 	teamAPI := r.Group("/team")
 	teamAPI.POST("/add", hTeam.CreateTeam)
 	teamAPI.GET("/get", hTeam.GetTeamByName)
+	teamAPI.POST("/codeOwners/:team_name", hTeam.UploadCodeOwners)
+	teamAPI.GET("/codeOwners/:team_name", hTeam.GetCodeOwners)
 
 	hUser := handlers.NewUserHandlerStruct(user, logger)
 	userAPI := r.Group("/users")
 	userAPI.POST("/setIsActive", hUser.SetActive)
+	userAPI.POST("/setMaxOpenReviews", hUser.SetMaxOpenReviews)
+	userAPI.POST("/setSchedule", hUser.SetSchedule)
 	userAPI.GET("/getReview", hUser.GetReview)
 
 	hPR := handlers.NewPullRequestHandlerStruct(pr, logger)
@@ -26,6 +30,48 @@ func SetupTestRouter(team services.TeamSer, user services.UserSer, pr services.P
 	prAPI.POST("/create", hPR.CreatePR)
 	prAPI.POST("/merge", hPR.MergePR)
 	prAPI.POST("/reassign", hPR.ReassignPR)
+	prAPI.POST("/addReviewer", hPR.AddReviewer)
+	prAPI.POST("/removeReviewer", hPR.RemoveReviewer)
+	prAPI.POST("/decline", hPR.DeclineReview)
+	prAPI.GET("/declines/user/:user_id", hPR.GetUserDeclines)
+	prAPI.GET("/declines/team/:team_name", hPR.GetTeamDeclines)
+	prAPI.POST("/submitReview", hPR.SubmitReview)
+	prAPI.PATCH("/update", hPR.UpdatePR)
+	prAPI.POST("/ready", hPR.ReadyPR)
+	prAPI.POST("/close", hPR.ClosePR)
+	prAPI.POST("/reopen", hPR.ReopenPR)
+	prAPI.GET("/reviews/:pull_request_id", hPR.GetReviewHistory)
+	prAPI.GET("/dependencies/:pull_request_id", hPR.GetDependencyGraph)
+	prAPI.POST("/revision", hPR.PushRevision)
+	prAPI.GET("/revisions/:pull_request_id", hPR.GetRevisions)
+	prAPI.GET("/timeline/:pull_request_id", hPR.GetTimeline)
+	prAPI.POST("/previewReviewers", hPR.PreviewReviewers)
+
+	return r
+}
+
+func SetupReviewerRuleRouter(rule services.ReviewerRuleSer) *gin.Engine {
+	r := gin.Default()
+	logger := zap.NewNop()
+
+	hRule := handlers.NewReviewerRuleHandlerStruct(rule, logger)
+	ruleAPI := r.Group("/reviewerRules")
+	ruleAPI.POST("/save", hRule.SaveRule)
+	ruleAPI.POST("/delete", hRule.DeleteRule)
+	ruleAPI.GET("/get/:user_id", hRule.GetRules)
+
+	return r
+}
+
+func SetupAbsenceRouter(absence services.AbsenceSer) *gin.Engine {
+	r := gin.Default()
+	logger := zap.NewNop()
+
+	hAbsence := handlers.NewAbsenceHandlerStruct(absence, logger)
+	absenceAPI := r.Group("/absences")
+	absenceAPI.POST("/add", hAbsence.AddAbsence)
+	absenceAPI.POST("/delete", hAbsence.DeleteAbsence)
+	absenceAPI.GET("/get/:user_id", hAbsence.GetAbsences)
 
 	return r
 }
@@ -2,11 +2,16 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -96,6 +101,65 @@ func TestTeamHandler_CreateTeam(t *testing.T) {
 	})
 }
 
+func TestTeamHandler_CreateTeamReviewersSettings(t *testing.T) {
+	t.Run("returns team reviewers settings", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"team_name":       testTeamName,
+			"reviewers_count": 3,
+			"min_reviewers":   1,
+			"members": []interface{}{
+				map[string]interface{}{
+					"user_id":   testUserID1,
+					"username":  testUsername1,
+					"is_active": true,
+				},
+			},
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"reviewers_count":3`)
+		assert.Contains(t, w.Body.String(), `"min_reviewers":1`)
+	})
+
+	t.Run("rejects zero reviewers count", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"team_name":       testTeamName,
+			"reviewers_count": 0,
+			"members":         []interface{}{},
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestTeamHandler_GetTeamByName(t *testing.T) {
 	t.Run("successfully retrieves existing team", func(t *testing.T) {
 		teamSvc := NewFakeTeamService()
@@ -151,3 +215,161 @@ func TestTeamHandler_GetTeamByName(t *testing.T) {
 			"handler returns 200 even for non-existent team")
 	})
 }
+
+func TestTeamHandler_CreateTeamSeniority(t *testing.T) {
+	t.Run("returns member levels and senior level", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"team_name":    testTeamName,
+			"senior_level": 3,
+			"members": []interface{}{
+				map[string]interface{}{
+					"user_id":   testUserID1,
+					"username":  testUsername1,
+					"is_active": true,
+					"level":     3,
+				},
+				map[string]interface{}{
+					"user_id":   testUserID2,
+					"username":  testUsername2,
+					"is_active": true,
+				},
+			},
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"senior_level":3`)
+		assert.Contains(t, w.Body.String(), `"level":3`)
+		assert.Contains(t, w.Body.String(), `"level":1`)
+	})
+
+	t.Run("rejects zero member level", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"team_name": testTeamName,
+			"members": []interface{}{
+				map[string]interface{}{
+					"user_id":   testUserID1,
+					"username":  testUsername1,
+					"is_active": true,
+					"level":     0,
+				},
+			},
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTeamHandler_CreateTeamTopUpFailure(t *testing.T) {
+	teamSvc := NewFakeTeamService()
+	teamSvc.topUpErr = validateError.ReviewersAtCapacity
+	userSvc := NewFakeUserService()
+	router := SetupTestRouter(teamSvc, userSvc, NewFakePRServiceWithUsers(userSvc))
+
+	body, err := json.Marshal(map[string]interface{}{
+		"team_name": testTeamName,
+		"members": []interface{}{
+			map[string]interface{}{"user_id": testUserID1, "username": testUsername1, "is_active": true},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"team_name":"backend"`)
+	assert.Contains(t, w.Body.String(), `"topped_up":[]`)
+}
+
+func TestTeamService_CreateTopUp(t *testing.T) {
+	ctx := context.Background()
+	newTeam := func() domain.Team {
+		team := MakeTestTeam(testTeamName, []string{"b1", "b2"})
+		team.ReviewersCount = domain.DefaultReviewersCount
+		return team
+	}
+
+	t.Run("team is kept when top-up fails", func(t *testing.T) {
+		store := NewMemoryStore(fixedNow)
+		topUpErr := errors.New("selector unavailable")
+		svc := newMemoryTeamService(store, failingTopUp{err: topUpErr})
+
+		created, topUps, err := svc.Create(ctx, newTeam())
+		assert.ErrorIs(t, err, validateError.TopUpFailed)
+		assert.ErrorIs(t, err, topUpErr)
+		assert.Empty(t, topUps)
+		assert.Len(t, created.Members, 2)
+
+		saved, err := store.Repos().Team.GetByName(ctx, testTeamName)
+		require.NoError(t, err)
+		assert.Equal(t, testTeamName, saved.Name)
+		assert.Equal(t, testTeamName, store.User("b1").TeamName)
+	})
+
+	t.Run("members moved from another team top up their open PRs", func(t *testing.T) {
+		store := NewMemoryStore(fixedNow)
+		seedDevTeam(store, domain.Team{})
+		store.AddPR(domain.PullRequestRead{Id: testPRID, Name: testPRName, AuthorId: "b1", Status: domain.StatusOpen})
+		store.AddTeam(MakeTestTeam("old", []string{"b1"}))
+		svc := newMemoryTeamService(store, newMemoryPRService(store))
+
+		_, topUps, err := svc.Create(ctx, newTeam())
+		require.NoError(t, err)
+		require.Len(t, topUps, 1)
+		assert.Equal(t, testPRID, topUps[0].PullRequestId)
+		assert.Equal(t, []domain.AssignedReviewer{{Id: "b2"}}, topUps[0].Added)
+	})
+}
+
+func TestTeamService_GetByNameAbsences(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(fixedNow)
+	seedDevTeam(store, domain.Team{})
+	absences := store.Repos().Absence
+	ended, err := absences.Create(ctx, domain.Absence{UserId: "u2", StartsAt: fixedNow.Add(-48 * time.Hour), EndsAt: fixedNow.Add(-time.Hour)})
+	require.NoError(t, err)
+	current, err := absences.Create(ctx, domain.Absence{UserId: "u3", StartsAt: fixedNow.Add(-time.Hour), EndsAt: fixedNow.Add(time.Hour)})
+	require.NoError(t, err)
+	svc := newMemoryTeamService(store, newMemoryPRService(store))
+
+	// отсутствия отбираются по часам сервиса, а не по текущему времени базы
+	team, err := svc.GetByName(ctx, testTeamDev)
+	require.NoError(t, err)
+
+	byUser := make(map[string][]domain.Absence)
+	for _, m := range team.Members {
+		byUser[m.ID] = m.Absences
+	}
+	assert.Empty(t, byUser["u2"], "absence %d has ended", ended.Id)
+	assert.Equal(t, []domain.Absence{current}, byUser["u3"])
+}
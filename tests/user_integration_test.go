@@ -3,11 +3,14 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/validateError"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -66,4 +69,219 @@ func TestUserHandler_SetActive(t *testing.T) {
 		assert.Contains(t, bodyStr, "user not found",
 			"expected error message about user not found, got: %s", w.Body.String())
 	})
+
+	t.Run("reports pull requests topped up on activation", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		userSvc.registeredUsers[testUserID2] = MakeTestUser(testUserID2, testUsername2, testTeamBackend, false)
+		userSvc.pendingTopUps = []domain.TopUp{{PullRequestId: "pr-1", Added: []domain.AssignedReviewer{{Id: testUserID2}}}}
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"user_id":   testUserID2,
+			"is_active": true,
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"topped_up":[{"pull_request_id":"pr-1"`)
+	})
+	t.Run("keeps activation when top-up fails", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		userSvc.registeredUsers[testUserID2] = MakeTestUser(testUserID2, testUsername2, testTeamBackend, false)
+		userSvc.topUpErr = validateError.ReviewersAtCapacity
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"user_id":   testUserID2,
+			"is_active": true,
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"is_active":true`)
+		assert.Contains(t, w.Body.String(), `"topped_up":[]`)
+	})
+}
+
+func TestUserHandler_SetMaxOpenReviews(t *testing.T) {
+	t.Run("successfully updates user capacity", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		userSvc.registeredUsers[testUserID2] = MakeTestUser(testUserID2, testUsername2, testTeamBackend, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"user_id":          testUserID2,
+			"max_open_reviews": 3,
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"max_open_reviews":3`)
+	})
+
+	t.Run("rejects negative capacity", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"user_id":          testUserID2,
+			"max_open_reviews": -1,
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("maps service errors", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			failWith error
+			status   int
+		}{
+			"unknown user":   {nil, http.StatusNotFound},
+			"internal error": {errors.New("connection reset"), http.StatusInternalServerError},
+		} {
+			userSvc := NewFakeUserService()
+			userSvc.failWith = tc.failWith
+			router := SetupTestRouter(NewFakeTeamService(), userSvc, NewFakePRServiceWithUsers(userSvc))
+
+			body, err := json.Marshal(map[string]interface{}{"user_id": testUserID2, "max_open_reviews": 3})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code, name)
+		}
+	})
+}
+
+func TestUserHandler_SetSchedule(t *testing.T) {
+	t.Run("successfully updates working hours", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+
+		userSvc.registeredUsers[testUserID2] = MakeTestUser(testUserID2, testUsername2, testTeamBackend, true)
+
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"user_id":    testUserID2,
+			"time_zone":  "Europe/Moscow",
+			"work_start": "09:30",
+			"work_end":   "18:00",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setSchedule", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"time_zone":"Europe/Moscow"`)
+		assert.Contains(t, w.Body.String(), `"work_start":"09:30"`)
+	})
+
+	t.Run("rejects malformed working hours", func(t *testing.T) {
+		teamSvc := NewFakeTeamService()
+		userSvc := NewFakeUserService()
+		prSvc := NewFakePRServiceWithUsers(userSvc)
+		router := SetupTestRouter(teamSvc, userSvc, prSvc)
+
+		payload := map[string]interface{}{
+			"user_id":    testUserID2,
+			"work_start": "9am",
+			"work_end":   "18:00",
+		}
+
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setSchedule", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("maps service errors", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			failWith error
+			status   int
+		}{
+			"unknown user":     {nil, http.StatusNotFound},
+			"invalid schedule": {validateError.InvalidSchedule, http.StatusBadRequest},
+			"internal error":   {errors.New("connection reset"), http.StatusInternalServerError},
+		} {
+			userSvc := NewFakeUserService()
+			userSvc.failWith = tc.failWith
+			router := SetupTestRouter(NewFakeTeamService(), userSvc, NewFakePRServiceWithUsers(userSvc))
+
+			body, err := json.Marshal(map[string]interface{}{"user_id": testUserID2, "time_zone": "Europe/Moscow"})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/setSchedule", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code, name)
+		}
+	})
 }
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linspacestrom/InterShipAv/internal/domain"
+	"github.com/linspacestrom/InterShipAv/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func minutes(hour, minute int) *int {
+	m := hour*60 + minute
+	return &m
+}
+
+// fixedNow — 10:00 UTC
+var fixedNow = time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+
+func TestWorkSchedule_UntilWork(t *testing.T) {
+	t.Run("inside working hours in own time zone", func(t *testing.T) {
+		schedule := domain.WorkSchedule{TimeZone: "Europe/Moscow", WorkStart: minutes(9, 0), WorkEnd: minutes(18, 0)}
+
+		assert.Equal(t, time.Duration(0), schedule.UntilWork(fixedNow))
+		assert.Equal(t, 13, schedule.LocalTime(fixedNow).Hour())
+	})
+
+	t.Run("counts time until next start", func(t *testing.T) {
+		schedule := domain.WorkSchedule{TimeZone: "UTC", WorkStart: minutes(11, 30), WorkEnd: minutes(20, 0)}
+
+		assert.Equal(t, 90*time.Minute, schedule.UntilWork(fixedNow))
+	})
+
+	t.Run("supports shifts over midnight", func(t *testing.T) {
+		schedule := domain.WorkSchedule{TimeZone: "UTC", WorkStart: minutes(22, 0), WorkEnd: minutes(6, 0)}
+
+		assert.True(t, schedule.InWorkingHours(time.Date(2026, time.March, 2, 3, 0, 0, 0, time.UTC)))
+		assert.Equal(t, 12*time.Hour, schedule.UntilWork(fixedNow))
+	})
+
+	t.Run("always available without hours", func(t *testing.T) {
+		assert.True(t, domain.WorkSchedule{TimeZone: "UTC"}.InWorkingHours(fixedNow))
+	})
+}
+
+func TestSplitByWorkingHours(t *testing.T) {
+	candidates := []domain.ReviewerCandidate{
+		{Id: "working", Schedule: domain.WorkSchedule{TimeZone: "UTC", WorkStart: minutes(9, 0), WorkEnd: minutes(17, 0)}},
+		{Id: "soon", Schedule: domain.WorkSchedule{TimeZone: "Asia/Tokyo", WorkStart: minutes(19, 30), WorkEnd: minutes(23, 0)}},
+		{Id: "asleep", Schedule: domain.WorkSchedule{TimeZone: "America/New_York", WorkStart: minutes(9, 0), WorkEnd: minutes(17, 0)}},
+	}
+
+	onDuty, offDuty := services.SplitByWorkingHours(candidates, fixedNow)
+
+	assert.Equal(t, []string{"working", "soon"}, []string{onDuty[0].Id, onDuty[1].Id})
+	assert.Len(t, offDuty, 1)
+	assert.Equal(t, "asleep", offDuty[0].Id)
+}